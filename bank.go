@@ -1,6 +1,10 @@
 package paystack
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
 
 // BankService handles operations related to the bank
 // For more details see https://developers.paystack.co/v1.0/reference#bank
@@ -8,24 +12,27 @@ type BankService service
 
 // Bank represents a Paystack bank
 type Bank struct {
-	ID        int    `json:"id,omitempty"`
-	CreatedAt string `json:"createdAt,omitempty"`
-	UpdatedAt string `json:"updatedAt,omitempty"`
-	Name      string `json:"name,omitempty"`
-	Slug      string `json:"slug,omitempty"`
-	Code      string `json:"code,omitempty"`
-	LongCode  string `json:"long_code,omitempty"`
-	Gateway   string `json:"gateway,omitempty"`
-	Active    bool   `json:"active,omitempty"`
-	IsDeleted bool   `json:"is_deleted,omitempty"`
+	ID        int       `json:"id,omitempty"`
+	CreatedAt Timestamp `json:"createdAt,omitzero"`
+	UpdatedAt Timestamp `json:"updatedAt,omitzero"`
+	Name      string    `json:"name,omitempty"`
+	Slug      string    `json:"slug,omitempty"`
+	Code      string    `json:"code,omitempty"`
+	LongCode  string    `json:"long_code,omitempty"`
+	Gateway   string    `json:"gateway,omitempty"`
+	Active    bool      `json:"active,omitempty"`
+	IsDeleted bool      `json:"is_deleted,omitempty"`
 }
 
-// BankList is a list object for banks.
-type BankList struct {
-	Meta   ListMeta
-	Values []Bank `json:"data,omitempty"`
+// UnmarshalJSON decodes b, accepting camelCase or snake_case timestamp keys.
+func (b *Bank) UnmarshalJSON(data []byte) error {
+	type bank Bank
+	return unmarshalTimestamps(data, (*bank)(b))
 }
 
+// BankList is a list object for banks.
+type BankList = List[Bank]
+
 // BVNResponse represents response from resolve_bvn endpoint
 type BVNResponse struct {
 	Meta struct {
@@ -35,26 +42,63 @@ type BVNResponse struct {
 	BVN string
 }
 
+// decodeEnvelope decodes the BVN from the response data and the call
+// counters from the response meta
+func (r *BVNResponse) decodeEnvelope(body []byte) (envelopeHeader, error) {
+	var env struct {
+		envelopeHeader
+		Data struct {
+			BVN string `json:"bvn"`
+		} `json:"data"`
+		Meta json.RawMessage `json:"meta"`
+	}
+	if err := json.Unmarshal(body, &env); err != nil {
+		return env.envelopeHeader, err
+	}
+	r.BVN = env.Data.BVN
+	if len(env.Meta) > 0 {
+		if err := json.Unmarshal(env.Meta, &r.Meta); err != nil {
+			return env.envelopeHeader, err
+		}
+	}
+	return env.envelopeHeader, nil
+}
+
 // List returns a list of all the banks.
 // For more details see https://developers.paystack.co/v1.0/reference#list-banks
 func (s *BankService) List() (*BankList, error) {
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *BankService) ListContext(ctx context.Context) (*BankList, error) {
 	banks := &BankList{}
-	err := s.client.Call("GET", "/bank", nil, banks)
+	err := s.client.call(ctx, "Bank.List", "GET", "/bank", nil, banks)
 	return banks, err
 }
 
 // ResolveBVN docs https://developers.paystack.co/v1.0/reference#resolve-bvn
 func (s *BankService) ResolveBVN(bvn int) (*BVNResponse, error) {
+	return s.ResolveBVNContext(context.Background(), bvn)
+}
+
+// ResolveBVNContext is like ResolveBVN but uses ctx for the request.
+func (s *BankService) ResolveBVNContext(ctx context.Context, bvn int) (*BVNResponse, error) {
 	u := fmt.Sprintf("/bank/resolve_bvn/%d", bvn)
 	resp := &BVNResponse{}
-	err := s.client.Call("GET", u, nil, resp)
+	err := s.client.call(ctx, "Bank.ResolveBVN", "GET", u, nil, resp)
 	return resp, err
 }
 
 // ResolveAccountNumber docs https://developers.paystack.co/v1.0/reference#resolve-account-number
 func (s *BankService) ResolveAccountNumber(accountNumber, bankCode string) (Response, error) {
+	return s.ResolveAccountNumberContext(context.Background(), accountNumber, bankCode)
+}
+
+// ResolveAccountNumberContext is like ResolveAccountNumber but uses ctx for the request.
+func (s *BankService) ResolveAccountNumberContext(ctx context.Context, accountNumber, bankCode string) (Response, error) {
 	u := fmt.Sprintf("/bank/resolve?account_number=%s&bank_code=%s", accountNumber, bankCode)
 	resp := Response{}
-	err := s.client.Call("GET", u, nil, &resp)
+	err := s.client.call(ctx, "Bank.ResolveAccountNumber", "GET", u, nil, &resp)
 	return resp, err
 }
@@ -3,6 +3,8 @@ package paystack
 import "testing"
 
 func TestBankList(t *testing.T) {
+	useClient(t)
+
 	// retrieve the bank list
 	banks, err := c.Bank.List()
 
@@ -12,6 +14,8 @@ func TestBankList(t *testing.T) {
 }
 
 func TestResolveBVN(t *testing.T) {
+	useClient(t)
+
 	// Test invlaid BVN.
 	// Err not nill. Resp status code is 400
 	resp, err := c.Bank.ResolveBVN(21212917)
@@ -30,6 +34,8 @@ func TestResolveBVN(t *testing.T) {
 }
 
 func TestResolveAccountNumber(t *testing.T) {
+	useClient(t)
+
 	resp, err := c.Bank.ResolveAccountNumber("0022728151", "063")
 	if err == nil {
 		t.Errorf("Expected error, got %+v'", resp)
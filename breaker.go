@@ -0,0 +1,309 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched, with errors.Is, by the *CircuitOpenError
+// returned for calls refused by an open circuit.
+var ErrCircuitOpen = errors.New("paystack: circuit open")
+
+// CircuitOpenError is returned without a request being made while the
+// circuit of an endpoint group is open.
+type CircuitOpenError struct {
+	Group string
+	// RetryAfter is how long until the circuit lets a trial request through.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("paystack: circuit open for %s, retry after %v", e.Group, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitState is the state of a circuit.
+type CircuitState int
+
+// Circuit states. A closed circuit lets requests through. An open circuit
+// refuses them until OpenTimeout has passed, when it becomes half-open and
+// lets HalfOpenRequests trial requests through; it closes again if they
+// succeed and reopens if one fails.
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreaker fails calls fast while Paystack is failing, rather than
+// letting them wait for timeouts. Server errors (5xx), timeouts and network
+// errors count as failures; any other response counts as a success.
+//
+// Each endpoint group has its own circuit, so that a failing endpoint does
+// not block unrelated ones. By default a group is the first segment of the
+// path, such as /bank or /transaction; Group defines narrower ones.
+// A breaker may be shared by several clients.
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failures that opens
+	// a circuit. Zero disables it.
+	FailureThreshold int
+
+	// FailureRate opens a circuit when the proportion of failed requests
+	// in Window reaches it, once at least MinRequests were made. Zero
+	// disables it.
+	FailureRate float64
+	MinRequests int
+	Window      time.Duration
+
+	// OpenTimeout is how long a circuit stays open before trial requests
+	// are let through.
+	OpenTimeout time.Duration
+
+	// HalfOpenRequests is the number of concurrent trial requests let
+	// through by a half-open circuit.
+	HalfOpenRequests int
+
+	// OnStateChange, when set, is called after the circuit of group changes
+	// state, e.g. to raise an alert when it opens.
+	OnStateChange func(group string, from, to CircuitState)
+
+	mu       sync.Mutex
+	groups   []string // sorted by descending length
+	circuits map[string]*circuit
+	now      func() time.Time
+}
+
+// NewCircuitBreaker returns a breaker that opens a circuit after 5
+// consecutive failures, or when half of at least 20 requests in a minute
+// fail, and tries again after 30 seconds.
+func NewCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureThreshold: 5,
+		FailureRate:      0.5,
+		MinRequests:      20,
+		Window:           time.Minute,
+		OpenTimeout:      30 * time.Second,
+		HalfOpenRequests: 1,
+	}
+}
+
+// Group gives requests whose path starts with prefix a circuit of their own.
+// It returns b so that groups can be chained onto NewCircuitBreaker.
+func (b *CircuitBreaker) Group(prefix string) *CircuitBreaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	prefix = "/" + strings.Trim(prefix, "/")
+	for _, g := range b.groups {
+		if g == prefix {
+			return b
+		}
+	}
+	b.groups = append(b.groups, prefix)
+	sort.Slice(b.groups, func(i, j int) bool {
+		return len(b.groups[i]) > len(b.groups[j])
+	})
+	return b
+}
+
+// State returns the state of the circuit that requests to path go through.
+func (b *CircuitBreaker) State(path string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	group := b.group(path)
+	cb, ok := b.circuits[group]
+	if !ok {
+		return CircuitClosed
+	}
+	if cb.state == CircuitOpen && !b.clock().Before(cb.openUntil) {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// circuit is the state of one endpoint group
+type circuit struct {
+	state      CircuitState
+	generation int // incremented on every state change
+	openUntil  time.Time
+	trials     int // trial requests in flight while half-open
+
+	consecutive int
+	windowStart time.Time
+	requests    int
+	failures    int
+}
+
+// stateChange is a transition to report once b.mu is released
+type stateChange struct {
+	group    string
+	from, to CircuitState
+}
+
+func (b *CircuitBreaker) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
+
+// group returns the group of path. b.mu must be held.
+func (b *CircuitBreaker) group(path string) string {
+	for _, g := range b.groups {
+		if path == g || strings.HasPrefix(path, g+"/") {
+			return g
+		}
+	}
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return "/" + segment
+}
+
+// allow reports whether a request to path may be made. If so, the returned
+// function must be called with the outcome of the request.
+func (b *CircuitBreaker) allow(ctx context.Context, path string) (func(resp *http.Response, err error), error) {
+	b.mu.Lock()
+	now := b.clock()
+	group := b.group(path)
+	if b.circuits == nil {
+		b.circuits = make(map[string]*circuit)
+	}
+	cb, ok := b.circuits[group]
+	if !ok {
+		cb = &circuit{windowStart: now}
+		b.circuits[group] = cb
+	}
+
+	var changes []stateChange
+	if cb.state == CircuitOpen && !now.Before(cb.openUntil) {
+		changes = append(changes, b.transition(group, cb, CircuitHalfOpen, now))
+	}
+	var err error
+	switch {
+	case cb.state == CircuitOpen:
+		err = &CircuitOpenError{Group: group, RetryAfter: cb.openUntil.Sub(now)}
+	case cb.state == CircuitHalfOpen && cb.trials >= max(b.HalfOpenRequests, 1):
+		err = &CircuitOpenError{Group: group}
+	case cb.state == CircuitHalfOpen:
+		cb.trials++
+	}
+	generation := cb.generation
+	b.mu.Unlock()
+	b.notify(changes)
+
+	if err != nil {
+		return nil, err
+	}
+	return func(resp *http.Response, err error) {
+		failed, counted := isCircuitFailure(ctx, resp, err)
+		b.record(group, cb, generation, failed, counted)
+	}, nil
+}
+
+// record updates the circuit of group with the outcome of a request let
+// through in generation. Outcomes of requests that started before the
+// last state change are ignored, as are those not counted.
+func (b *CircuitBreaker) record(group string, cb *circuit, generation int, failed, counted bool) {
+	b.mu.Lock()
+	now := b.clock()
+	var changes []stateChange
+
+	if cb.generation == generation {
+		switch cb.state {
+		case CircuitHalfOpen:
+			cb.trials--
+			if !counted {
+				break
+			}
+			if failed {
+				changes = append(changes, b.transition(group, cb, CircuitOpen, now))
+			} else if cb.trials == 0 {
+				changes = append(changes, b.transition(group, cb, CircuitClosed, now))
+			}
+		case CircuitClosed:
+			if !counted {
+				break
+			}
+			if b.Window > 0 && now.Sub(cb.windowStart) >= b.Window {
+				cb.windowStart, cb.requests, cb.failures = now, 0, 0
+			}
+			cb.requests++
+			if failed {
+				cb.failures++
+				cb.consecutive++
+			} else {
+				cb.consecutive = 0
+			}
+			if b.tripped(cb) {
+				changes = append(changes, b.transition(group, cb, CircuitOpen, now))
+			}
+		}
+	}
+	b.mu.Unlock()
+	b.notify(changes)
+}
+
+// tripped reports whether a closed circuit should open. b.mu must be held.
+func (b *CircuitBreaker) tripped(cb *circuit) bool {
+	if b.FailureThreshold > 0 && cb.consecutive >= b.FailureThreshold {
+		return true
+	}
+	return b.FailureRate > 0 && cb.requests >= max(b.MinRequests, 1) &&
+		float64(cb.failures)/float64(cb.requests) >= b.FailureRate
+}
+
+// transition moves cb to state. b.mu must be held.
+func (b *CircuitBreaker) transition(group string, cb *circuit, state CircuitState, now time.Time) stateChange {
+	change := stateChange{group: group, from: cb.state, to: state}
+	cb.state = state
+	cb.generation++
+	cb.trials = 0
+	cb.consecutive, cb.requests, cb.failures, cb.windowStart = 0, 0, 0, now
+	if state == CircuitOpen {
+		cb.openUntil = now.Add(b.OpenTimeout)
+	}
+	return change
+}
+
+func (b *CircuitBreaker) notify(changes []stateChange) {
+	if b.OnStateChange == nil {
+		return
+	}
+	for _, c := range changes {
+		b.OnStateChange(c.group, c.from, c.to)
+	}
+}
+
+// isCircuitFailure reports whether the outcome of a request counts against
+// the circuit. Requests canceled by the caller count neither way.
+func isCircuitFailure(ctx context.Context, resp *http.Response, err error) (failed, counted bool) {
+	if err != nil {
+		if ctx.Err() == context.Canceled {
+			return false, false
+		}
+		return true, true
+	}
+	return resp.StatusCode >= 500, true
+}
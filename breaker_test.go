@@ -0,0 +1,186 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var resolveDown atomic.Bool
+	resolveDown.Store(true)
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/bank/resolve" && resolveDown.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `{"status":false,"message":"Bad gateway"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":true,"message":"ok","data":{"id":1}}`)
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker().Group("/bank/resolve")
+	breaker.FailureThreshold = 3
+	breaker.now = func() time.Time { return now }
+	var changes []string
+	breaker.OnStateChange = func(group string, from, to CircuitState) {
+		changes = append(changes, fmt.Sprintf("%s %v->%v", group, from, to))
+	}
+
+	client, err := NewClientWithOptions("sk_test_key", WithBaseURL(srv.URL), WithCircuitBreaker(breaker))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		client.Bank.ResolveAccountNumber("0022728151", "063")
+	}
+	if breaker.State("/bank/resolve") != CircuitOpen {
+		t.Fatalf("Expected circuit to open after 3 failures, got %v", breaker.State("/bank/resolve"))
+	}
+
+	_, err = client.Bank.ResolveAccountNumber("0022728151", "063")
+	var openErr *CircuitOpenError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) || openErr.Group != "/bank/resolve" {
+		t.Errorf("Expected ErrCircuitOpen for /bank/resolve, got %v", err)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("Expected an open circuit to fail without a request, got %d requests", requests.Load())
+	}
+	if ErrorClass(err) != "circuit_open" {
+		t.Errorf("Expected error class circuit_open, got %q", ErrorClass(err))
+	}
+
+	// other endpoint groups are unaffected
+	if _, err := client.Transaction.Verify("ref1"); err != nil {
+		t.Errorf("Expected /transaction/verify to succeed, got %v", err)
+	}
+	if _, err := client.Bank.ResolveBVN(12345678901); err != nil {
+		t.Errorf("Expected /bank/resolve_bvn to succeed, got %v", err)
+	}
+
+	// a successful trial request closes the circuit
+	now = now.Add(breaker.OpenTimeout)
+	resolveDown.Store(false)
+	if _, err := client.Bank.ResolveAccountNumber("0022728151", "063"); err != nil {
+		t.Errorf("Expected trial request to succeed, got %v", err)
+	}
+	want := "[/bank/resolve closed->open /bank/resolve open->half-open /bank/resolve half-open->closed]"
+	if fmt.Sprint(changes) != want {
+		t.Errorf("Expected state changes %s, got %v", want, changes)
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	now := time.Now()
+	b := NewCircuitBreaker()
+	b.FailureThreshold = 1
+	b.now = func() time.Time { return now }
+	ctx := context.Background()
+	fail := &http.Response{StatusCode: 503}
+
+	done, _ := b.allow(ctx, "/transfer")
+	done(fail, nil)
+	now = now.Add(b.OpenTimeout)
+
+	trial, err := b.allow(ctx, "/transfer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.allow(ctx, "/transfer/finalize_transfer"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected only one trial request while half-open, got %v", err)
+	}
+	trial(nil, errors.New("dial tcp: connection refused"))
+	if b.State("/transfer") != CircuitOpen {
+		t.Errorf("Expected a failed trial to reopen the circuit, got %v", b.State("/transfer"))
+	}
+}
+
+func TestCircuitBreakerRateLimitedTrial(t *testing.T) {
+	var down atomic.Bool
+	down.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `{"status":false,"message":"Bad gateway"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":true,"message":"ok","data":[]}`)
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker()
+	breaker.FailureThreshold = 1
+	breaker.now = func() time.Time { return now }
+	limiter := NewRateLimiter(1000, 10).Group("/bank/resolve", 0.001, 1)
+	client, err := NewClientWithOptions("sk_test_key", WithBaseURL(srv.URL),
+		WithCircuitBreaker(breaker), WithRateLimiter(limiter))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.Bank.ResolveAccountNumber("0022728151", "063") // opens /bank
+	now = now.Add(breaker.OpenTimeout)
+	down.Store(false)
+
+	// a would-be trial cancelled while waiting for the rate limiter must not
+	// hold the half-open circuit's trial slot
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.Bank.ResolveAccountNumberContext(ctx, "0022728151", "063"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the rate limiter wait to time out, got %v", err)
+	}
+	if _, err := client.Bank.List(); err != nil {
+		t.Errorf("Expected a trial request to be let through, got %v", err)
+	}
+	if breaker.State("/bank") != CircuitClosed {
+		t.Errorf("Expected the trial to close the circuit, got %v", breaker.State("/bank"))
+	}
+}
+
+func TestCircuitBreakerFailureRate(t *testing.T) {
+	b := NewCircuitBreaker()
+	b.FailureThreshold = 0
+	b.MinRequests = 10
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		status := 200
+		if i%2 == 0 {
+			status = 500
+		}
+		if i == 9 && b.State("/charge") != CircuitClosed {
+			t.Fatalf("Expected circuit to stay closed below MinRequests")
+		}
+		done, err := b.allow(ctx, "/charge")
+		if err != nil {
+			t.Fatal(err)
+		}
+		done(&http.Response{StatusCode: status}, nil)
+	}
+	if b.State("/charge") != CircuitOpen {
+		t.Errorf("Expected a 50%% failure rate to open the circuit")
+	}
+
+	// client errors and canceled requests do not count as failures
+	b = NewCircuitBreaker()
+	b.FailureThreshold = 1
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	done, _ := b.allow(canceled, "/charge")
+	done(nil, context.Canceled)
+	done, _ = b.allow(ctx, "/charge")
+	done(&http.Response{StatusCode: 400}, nil)
+	if b.State("/charge") != CircuitClosed {
+		t.Errorf("Expected circuit to stay closed, got %v", b.State("/charge"))
+	}
+}
@@ -1,6 +1,9 @@
 package paystack
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // BulkChargeService handles operations related to the bulkcharge
 // For more details see https://developers.paystack.co/v1.0/reference#initiate-bulk-charge
@@ -9,15 +12,21 @@ type BulkChargeService service
 // BulkChargeBatch represents a bulk charge batch object
 // For more details see https://developers.paystack.co/v1.0/reference#initiate-bulk-charge
 type BulkChargeBatch struct {
-	ID            int    `json:"id,omitempty"`
-	CreatedAt     string `json:"createdAt,omitempty"`
-	UpdatedAt     string `json:"updatedAt,omitempty"`
-	BatchCode     string `json:"batch_code,omitempty"`
-	Status        string `json:"status,omitempty"`
-	Integration   int    `json:"integration,omitempty"`
-	Domain        string `json:"domain,omitempty"`
-	TotalCharges  string `json:"total_charges,omitempty"`
-	PendingCharge string `json:"pending_charge,omitempty"`
+	ID            int       `json:"id,omitempty"`
+	CreatedAt     Timestamp `json:"createdAt,omitzero"`
+	UpdatedAt     Timestamp `json:"updatedAt,omitzero"`
+	BatchCode     string    `json:"batch_code,omitempty"`
+	Status        string    `json:"status,omitempty"`
+	Integration   int       `json:"integration,omitempty"`
+	Domain        string    `json:"domain,omitempty"`
+	TotalCharges  int       `json:"total_charges,omitempty"`
+	PendingCharge int       `json:"pending_charges,omitempty"`
+}
+
+// UnmarshalJSON decodes b, accepting camelCase or snake_case timestamp keys.
+func (b *BulkChargeBatch) UnmarshalJSON(data []byte) error {
+	type bulkChargeBatch BulkChargeBatch
+	return unmarshalTimestamps(data, (*bulkChargeBatch)(b))
 }
 
 // BulkChargeRequest is an array of objects with authorization codes and amount
@@ -27,48 +36,73 @@ type BulkChargeRequest struct {
 
 // BulkItem represents a single bulk charge request item
 type BulkItem struct {
-	Authorization string  `json:"authorization,omitempty"`
-	Amount        float32 `json:"amount,omitempty"`
+	Authorization string `json:"authorization,omitempty"`
+	Amount        Money  `json:"amount,omitzero"`
+	Currency      string `json:"currency,omitempty"`
 }
 
 // BulkChargeBatchList is a list object for bulkcharges.
-type BulkChargeBatchList struct {
-	Meta   ListMeta
-	Values []BulkChargeBatch `json:"data,omitempty"`
-}
+type BulkChargeBatchList = List[BulkChargeBatch]
 
 // Initiate initiates a new bulkcharge
 // For more details see https://developers.paystack.co/v1.0/reference#initiate-bulk-charge
 func (s *BulkChargeService) Initiate(req *BulkChargeRequest) (*BulkChargeBatch, error) {
+	return s.InitiateContext(context.Background(), req)
+}
+
+// InitiateContext is like Initiate but uses ctx for the request.
+func (s *BulkChargeService) InitiateContext(ctx context.Context, req *BulkChargeRequest) (*BulkChargeBatch, error) {
 	bulkcharge := &BulkChargeBatch{}
-	err := s.client.Call("POST", "/bulkcharge", req.Items, bulkcharge)
+	err := s.client.call(ctx, "BulkCharge.Initiate", "POST", "/bulkcharge", req.Items, bulkcharge)
 	return bulkcharge, err
 }
 
 // List returns a list of bulkcharges.
 // For more details see https://developers.paystack.co/v1.0/reference#list-bulkcharges
 func (s *BulkChargeService) List() (*BulkChargeBatchList, error) {
-	return s.ListN(10, 0)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *BulkChargeService) ListContext(ctx context.Context) (*BulkChargeBatchList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // ListN returns a list of bulkcharges
 // For more details see https://developers.paystack.co/v1.0/reference#list-bulkcharges
 func (s *BulkChargeService) ListN(count, offset int) (*BulkChargeBatchList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *BulkChargeService) ListNContext(ctx context.Context, count, offset int) (*BulkChargeBatchList, error) {
 	u := paginateURL("/bulkcharge", count, offset)
 	bulkcharges := &BulkChargeBatchList{}
-	err := s.client.Call("GET", u, nil, bulkcharges)
+	err := s.client.call(ctx, "BulkCharge.ListN", "GET", u, nil, bulkcharges)
 	return bulkcharges, err
 }
 
+// Iter returns an iterator over all bulk charge batches, fetching perPage of them at a time.
+func (s *BulkChargeService) Iter(ctx context.Context, perPage int) *Iterator[BulkChargeBatch] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[BulkChargeBatch], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
+
 // Get returns a bulk charge batch
 // This endpoint retrieves a specific batch code.
 // It also returns useful information on its progress by way of
 // the total_charges and pending_charges attributes.
 // For more details see https://developers.paystack.co/v1.0/reference#fetch-bulk-charge-batch
 func (s *BulkChargeService) Get(idCode string) (*BulkChargeBatch, error) {
+	return s.GetContext(context.Background(), idCode)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *BulkChargeService) GetContext(ctx context.Context, idCode string) (*BulkChargeBatch, error) {
 	u := fmt.Sprintf("/bulkcharge/%s", idCode)
 	bulkcharge := &BulkChargeBatch{}
-	err := s.client.Call("GET", u, nil, bulkcharge)
+	err := s.client.call(ctx, "BulkCharge.Get", "GET", u, nil, bulkcharge)
 	return bulkcharge, err
 }
 
@@ -78,18 +112,28 @@ func (s *BulkChargeService) Get(idCode string) (*BulkChargeBatch, error) {
 // Charge statuses can be pending, success or failed.
 // For more details see https://developers.paystack.co/v1.0/reference#fetch-charges-in-a-batch
 func (s *BulkChargeService) GetBatchCharges(idCode string) (Response, error) {
+	return s.GetBatchChargesContext(context.Background(), idCode)
+}
+
+// GetBatchChargesContext is like GetBatchCharges but uses ctx for the request.
+func (s *BulkChargeService) GetBatchChargesContext(ctx context.Context, idCode string) (Response, error) {
 	u := fmt.Sprintf("/bulkcharge/%s/charges", idCode)
 	resp := Response{}
-	err := s.client.Call("GET", u, nil, &resp)
+	err := s.client.call(ctx, "BulkCharge.GetBatchCharges", "GET", u, nil, &resp)
 	return resp, err
 }
 
 // PauseBulkCharge stops processing a batch
 // For more details see https://developers.paystack.co/v1.0/reference#pause-bulk-charge-batch
 func (s *BulkChargeService) PauseBulkCharge(batchCode string) (Response, error) {
+	return s.PauseBulkChargeContext(context.Background(), batchCode)
+}
+
+// PauseBulkChargeContext is like PauseBulkCharge but uses ctx for the request.
+func (s *BulkChargeService) PauseBulkChargeContext(ctx context.Context, batchCode string) (Response, error) {
 	u := fmt.Sprintf("/bulkcharge/pause/%s", batchCode)
 	resp := Response{}
-	err := s.client.Call("GET", u, nil, &resp)
+	err := s.client.call(ctx, "BulkCharge.PauseBulkCharge", "GET", u, nil, &resp)
 
 	return resp, err
 }
@@ -97,9 +141,14 @@ func (s *BulkChargeService) PauseBulkCharge(batchCode string) (Response, error)
 // ResumeBulkCharge stops processing a batch
 // For more details see https://developers.paystack.co/v1.0/reference#resume-bulk-charge-batch
 func (s *BulkChargeService) ResumeBulkCharge(batchCode string) (Response, error) {
+	return s.ResumeBulkChargeContext(context.Background(), batchCode)
+}
+
+// ResumeBulkChargeContext is like ResumeBulkCharge but uses ctx for the request.
+func (s *BulkChargeService) ResumeBulkChargeContext(ctx context.Context, batchCode string) (Response, error) {
 	u := fmt.Sprintf("/bulkcharge/resume/%s", batchCode)
 	resp := Response{}
-	err := s.client.Call("GET", u, nil, &resp)
+	err := s.client.call(ctx, "BulkCharge.ResumeBulkCharge", "GET", u, nil, &resp)
 
 	return resp, err
 }
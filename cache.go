@@ -0,0 +1,223 @@
+package paystack
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cache stores raw response bodies of reference-data endpoints.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for key, if it has not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value for key for ttl.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// DefaultCacheTTLs returns how long responses of the reference-data
+// endpoints are cached by default, keyed by operation.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"Bank.List": 24 * time.Hour,
+		"DedicatedVirtualAccount.GetBankProviders": 24 * time.Hour,
+		"Client.ResolveCardBIN":                    24 * time.Hour,
+		"Bank.ResolveAccountNumber":                time.Hour,
+	}
+}
+
+// LRUCache is an in-memory Cache holding at most a fixed number of
+// entries, evicting the least recently used one when full.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List // front is most recently used
+	items    map[string]*list.Element
+	now      func() time.Time
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache returns an LRUCache holding up to capacity entries.
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRUCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get returns the value stored for key, if it has not expired.
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*lruEntry)
+	if !c.now().Before(e.expires) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return e.value, true
+}
+
+// Set stores value for key for ttl.
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(ttl)
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*lruEntry)
+		e.value, e.expires = value, expires
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of entries in the cache, including expired
+// entries not yet evicted.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// flightGroup coalesces concurrent calls with the same key into one
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done chan struct{}
+	val  []byte
+	err  error
+}
+
+// do calls fn once for all concurrent callers with key. The caller that
+// ran fn gets leader true; the others wait for its result or until done
+// is closed.
+func (g *flightGroup) do(key string, done <-chan struct{}, fn func() ([]byte, error)) (val []byte, leader bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight)
+	}
+	if f, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.val, false, f.err
+		case <-done:
+			return nil, false, errFlightAbandoned
+		}
+	}
+	f := &flight{done: make(chan struct{})}
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	f.val, f.err = fn()
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(f.done)
+	return f.val, true, f.err
+}
+
+// errFlightAbandoned is returned to a waiting caller whose context is done;
+// the caller reports its context error instead
+var errFlightAbandoned = errors.New("paystack: abandoned wait for coalesced call")
+
+// cacheTTL returns how long responses of op are cached, or 0 if not at all
+func (c *Client) cacheTTL(op Operation) time.Duration {
+	ttls := c.CacheTTLs
+	if ttls == nil {
+		ttls = defaultCacheTTLs
+	}
+	return ttls[op.String()]
+}
+
+var defaultCacheTTLs = DefaultCacheTTLs()
+
+// cacheKey identifies a request. It includes a fingerprint of the API key
+// so that clients with different keys can share a Cache.
+func (c *Client) cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(c.key))
+	return hex.EncodeToString(sum[:8]) + " " + req.Method + " " + req.URL.String()
+}
+
+// cached wraps next, answering GET requests of cacheable operations from
+// c.Cache and coalescing concurrent identical requests.
+func (c *Client) cached(next Handler) Handler {
+	return func(inv *Invocation) error {
+		ttl := c.cacheTTL(inv.Operation)
+		if ttl <= 0 || inv.Request.Method != http.MethodGet {
+			return next(inv)
+		}
+
+		key := c.cacheKey(inv.Request)
+		if body, ok := c.Cache.Get(key); ok {
+			return c.decodeCached(inv, body)
+		}
+
+		ctx := inv.Request.Context()
+		for {
+			body, leader, err := c.flights.do(key, ctx.Done(), func() ([]byte, error) {
+				if err := next(inv); err != nil {
+					return nil, err
+				}
+				c.Cache.Set(key, inv.Response.Body, ttl)
+				return inv.Response.Body, nil
+			})
+			switch {
+			case err == errFlightAbandoned:
+				return ctx.Err()
+			case !leader && isContextError(err) && ctx.Err() == nil:
+				// the context of the caller that made the request ended,
+				// not ours: make the request again
+				continue
+			case leader || err != nil:
+				return err
+			}
+			return c.decodeCached(inv, body)
+		}
+	}
+}
+
+// isContextError reports whether err comes from a context being done
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// decodeCached decodes a cached response body into inv.Result
+func (c *Client) decodeCached(inv *Invocation, body []byte) error {
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: inv.Request}
+	hdr, err := c.decodeBody(resp, body, inv.Result)
+	inv.Response = newResponseInfo(resp, body, hdr.Message, 0, 0)
+	inv.Response.Cached = true
+	return err
+}
@@ -0,0 +1,163 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	now := time.Now()
+	cache := NewLRUCache(2)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Hour)
+	cache.Get("a") // b is now least recently used
+	cache.Set("c", []byte("3"), time.Hour)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("Expected least recently used entry to be evicted")
+	}
+	if v, ok := cache.Get("a"); !ok || string(v) != "1" {
+		t.Errorf("Expected a to be cached, got %q", v)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("Expected a to have expired")
+	}
+	if _, ok := cache.Get("c"); !ok || cache.Len() != 1 {
+		t.Errorf("Expected only c to remain, got %d entries", cache.Len())
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/bank" {
+			<-release
+		}
+		fmt.Fprint(w, `{"status":true,"message":"Banks retrieved","data":[{"id":1,"name":"Access Bank","code":"044"}],"meta":{}}`)
+	}))
+	defer srv.Close()
+
+	client, err := NewClientWithOptions("sk_test_key", WithBaseURL(srv.URL), WithCache(NewLRUCache(100), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// concurrent identical lookups make one request
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			banks, err := client.Bank.List()
+			if err != nil || len(banks.Values) != 1 || banks.Values[0].Code != "044" {
+				t.Errorf("Unexpected banks %+v, %v", banks, err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if requests.Load() != 1 {
+		t.Errorf("Expected concurrent lookups to share 1 request, got %d", requests.Load())
+	}
+
+	var info ResponseInfo
+	if _, err := client.Bank.ListContext(WithResponseInfo(context.Background(), &info)); err != nil {
+		t.Fatal(err)
+	}
+	if requests.Load() != 1 || !info.Cached || info.Message != "Banks retrieved" {
+		t.Errorf("Expected a cached response, got %d requests and %+v", requests.Load(), info)
+	}
+
+	// operations without a TTL are not cached
+	client.Transaction.Verify("ref1")
+	client.Transaction.Verify("ref1")
+	if requests.Load() != 3 {
+		t.Errorf("Expected uncached operations to reach the server, got %d requests", requests.Load())
+	}
+}
+
+func TestClientCacheSkipsErrors(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"status":false,"message":"Could not resolve account name"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":true,"message":"Account number resolved","data":{"account_name":"DOE JOHN"}}`)
+	}))
+	defer srv.Close()
+
+	client, _ := NewClientWithOptions("sk_test_key", WithBaseURL(srv.URL),
+		WithCache(NewLRUCache(100), map[string]time.Duration{"Bank.ResolveAccountNumber": time.Minute}))
+
+	if _, err := client.Bank.ResolveAccountNumber("0022728151", "063"); err == nil {
+		t.Fatal("Expected first lookup to fail")
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Bank.ResolveAccountNumber("0022728151", "063")
+		if err != nil || resp["account_name"] != "DOE JOHN" {
+			t.Errorf("Unexpected response %v, %v", resp, err)
+		}
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected the error not to be cached, got %d requests", requests.Load())
+	}
+}
+
+func TestClientCacheLeaderCancelled(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			<-release
+		}
+		fmt.Fprint(w, `{"status":true,"message":"Banks retrieved","data":[{"id":1,"name":"Access Bank","code":"044"}],"meta":{}}`)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client, err := NewClientWithOptions("sk_test_key", WithBaseURL(srv.URL), WithCache(NewLRUCache(100), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() {
+		_, err := client.Bank.ListContext(ctx)
+		leader <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	follower := make(chan error)
+	go func() {
+		_, err := client.Bank.ListContext(context.Background())
+		follower <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancelled caller to fail with context.Canceled, got %v", err)
+	}
+	if err := <-follower; err != nil {
+		t.Errorf("Expected the waiting caller to make the request again, got %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests.Load())
+	}
+}
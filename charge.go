@@ -1,6 +1,7 @@
 package paystack
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -32,7 +33,8 @@ type BankAccount struct {
 // ChargeRequest represents a Paystack charge request
 type ChargeRequest struct {
 	Email             string       `json:"email,omitempty"`
-	Amount            float32      `json:"amount,omitempty"`
+	Amount            Money        `json:"amount,omitzero"`
+	Currency          string       `json:"currency,omitempty"`
 	Birthday          string       `json:"birthday,omitempty"`
 	Card              *Card        `json:"card,omitempty"`
 	Bank              *BankAccount `json:"bank,omitempty"`
@@ -44,60 +46,90 @@ type ChargeRequest struct {
 // Create submits a charge request using card details or bank details or authorization code
 // For more details see https://developers.paystack.co/v1.0/reference#charge
 func (s *ChargeService) Create(req *ChargeRequest) (Response, error) {
+	return s.CreateContext(context.Background(), req)
+}
+
+// CreateContext is like Create but uses ctx for the request.
+func (s *ChargeService) CreateContext(ctx context.Context, req *ChargeRequest) (Response, error) {
 	resp := Response{}
-	err := s.client.Call("POST", "/charge", req, &resp)
+	err := s.client.call(ctx, "Charge.Create", "POST", "/charge", req, &resp)
 	return resp, err
 }
 
 // Tokenize tokenizes payment instrument before a charge
 // For more details see https://developers.paystack.co/v1.0/reference#charge-tokenize
 func (s *ChargeService) Tokenize(req *ChargeRequest) (Response, error) {
+	return s.TokenizeContext(context.Background(), req)
+}
+
+// TokenizeContext is like Tokenize but uses ctx for the request.
+func (s *ChargeService) TokenizeContext(ctx context.Context, req *ChargeRequest) (Response, error) {
 	resp := Response{}
-	err := s.client.Call("POST", "/charge/tokenize", req, &resp)
+	err := s.client.call(ctx, "Charge.Tokenize", "POST", "/charge/tokenize", req, &resp)
 	return resp, err
 }
 
 // SubmitPIN submits PIN to continue a charge
 // For more details see https://developers.paystack.co/v1.0/reference#submit-pin
 func (s *ChargeService) SubmitPIN(pin, reference string) (Response, error) {
+	return s.SubmitPINContext(context.Background(), pin, reference)
+}
+
+// SubmitPINContext is like SubmitPIN but uses ctx for the request.
+func (s *ChargeService) SubmitPINContext(ctx context.Context, pin, reference string) (Response, error) {
 	data := url.Values{}
 	data.Add("pin", pin)
 	data.Add("reference", reference)
 	resp := Response{}
-	err := s.client.Call("POST", "/charge/submit_pin", data, &resp)
+	err := s.client.call(ctx, "Charge.SubmitPIN", "POST", "/charge/submit_pin", data, &resp)
 	return resp, err
 }
 
 // SubmitOTP submits OTP to continue a charge
 // For more details see https://developers.paystack.co/v1.0/reference#submit-pin
 func (s *ChargeService) SubmitOTP(otp, reference string) (Response, error) {
+	return s.SubmitOTPContext(context.Background(), otp, reference)
+}
+
+// SubmitOTPContext is like SubmitOTP but uses ctx for the request.
+func (s *ChargeService) SubmitOTPContext(ctx context.Context, otp, reference string) (Response, error) {
 	data := url.Values{}
 	data.Add("pin", otp)
 	data.Add("reference", reference)
 	resp := Response{}
-	err := s.client.Call("POST", "/charge/submit_otp", data, &resp)
+	err := s.client.call(ctx, "Charge.SubmitOTP", "POST", "/charge/submit_otp", data, &resp)
 	return resp, err
 }
 
 // SubmitPhone submits Phone when requested
 // For more details see https://developers.paystack.co/v1.0/reference#submit-pin
 func (s *ChargeService) SubmitPhone(phone, reference string) (Response, error) {
+	return s.SubmitPhoneContext(context.Background(), phone, reference)
+}
+
+// SubmitPhoneContext is like SubmitPhone but uses ctx for the request.
+func (s *ChargeService) SubmitPhoneContext(ctx context.Context, phone, reference string) (Response, error) {
 	data := url.Values{}
 	data.Add("pin", phone)
 	data.Add("reference", reference)
 	resp := Response{}
-	err := s.client.Call("POST", "/charge/submit_phone", data, &resp)
+	err := s.client.call(ctx, "Charge.SubmitPhone", "POST", "/charge/submit_phone", data, &resp)
 	return resp, err
 }
 
 // SubmitBirthday submits Birthday when requested
 // For more details see https://developers.paystack.co/v1.0/reference#submit-pin
 func (s *ChargeService) SubmitBirthday(birthday, reference string) (Response, error) {
+	return s.SubmitBirthdayContext(context.Background(), birthday, reference)
+}
+
+// SubmitBirthdayContext is like SubmitBirthday but uses ctx for the request.
+func (s *ChargeService) SubmitBirthdayContext(ctx context.Context, birthday, reference string) (Response, error) {
 	data := url.Values{}
 	data.Add("pin", birthday)
 	data.Add("reference", reference)
 	resp := Response{}
-	err := s.client.Call("POST", "/charge/submit_birthday", data, &resp)
+	err := s.client.call(ctx, "Charge.SubmitBirthday", "POST", "/charge/submit_birthday", data, &resp)
 	return resp, err
 }
 
@@ -106,8 +138,13 @@ func (s *ChargeService) SubmitBirthday(birthday, reference string) (Response, er
 // then make a check to see if its status has changed. Don't call too early as you may get a lot more pending than you should.
 // For more details see https://developers.paystack.co/v1.0/reference#check-pending-charge
 func (s *ChargeService) CheckPending(reference string) (Response, error) {
+	return s.CheckPendingContext(context.Background(), reference)
+}
+
+// CheckPendingContext is like CheckPending but uses ctx for the request.
+func (s *ChargeService) CheckPendingContext(ctx context.Context, reference string) (Response, error) {
 	u := fmt.Sprintf("/charge/%s", reference)
 	resp := Response{}
-	err := s.client.Call("GET", u, nil, &resp)
+	err := s.client.call(ctx, "Charge.CheckPending", "GET", u, nil, &resp)
 	return resp, err
 }
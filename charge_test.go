@@ -5,6 +5,8 @@ import (
 )
 
 func TestChargeServiceCreate(t *testing.T) {
+	useClient(t)
+
 	bankAccount := BankAccount{
 		Code:          "057",
 		AccountNumber: "0000000000",
@@ -12,7 +14,7 @@ func TestChargeServiceCreate(t *testing.T) {
 
 	charge := ChargeRequest{
 		Email:    "your_own_email_here@gmail.com",
-		Amount:   10000,
+		Amount:   NewMoney(10000, "NGN"),
 		Bank:     &bankAccount,
 		Birthday: "1999-12-31",
 	}
@@ -28,6 +30,8 @@ func TestChargeServiceCreate(t *testing.T) {
 }
 
 func TestChargeServiceCheckPending(t *testing.T) {
+	useClient(t)
+
 	bankAccount := BankAccount{
 		Code:          "057",
 		AccountNumber: "0000000000",
@@ -35,7 +39,7 @@ func TestChargeServiceCheckPending(t *testing.T) {
 
 	charge := ChargeRequest{
 		Email:    "your_own_email_here@gmail.com",
-		Amount:   10000,
+		Amount:   NewMoney(10000, "NGN"),
 		Bank:     &bankAccount,
 		Birthday: "1999-12-31",
 	}
@@ -1,8 +1,10 @@
 package paystack
 
 import (
+	"context"
 	"fmt"
 	"net/url"
+	"time"
 )
 
 // CustomerService handles operations related to the customer
@@ -13,8 +15,8 @@ type CustomerService service
 // For more details see https://developers.paystack.co/v1.0/reference#create-customer
 type Customer struct {
 	ID             int            `json:"id,omitempty"`
-	CreatedAt      string         `json:"createdAt,omitempty"`
-	UpdatedAt      string         `json:"updatedAt,omitempty"`
+	CreatedAt      Timestamp      `json:"createdAt,omitzero"`
+	UpdatedAt      Timestamp      `json:"updatedAt,omitzero"`
 	Domain         string         `json:"domain,omitempty"`
 	Integration    int            `json:"integration,omitempty"`
 	FirstName      string         `json:"first_name,omitempty"`
@@ -28,18 +30,33 @@ type Customer struct {
 	RiskAction     string         `json:"risk_action"`
 }
 
+// UnmarshalJSON decodes c, accepting camelCase or snake_case timestamp keys.
+func (c *Customer) UnmarshalJSON(data []byte) error {
+	type customer Customer
+	return unmarshalTimestamps(data, (*customer)(c))
+}
+
 // CustomerList is a list object for customers.
-type CustomerList struct {
-	Meta   ListMeta
-	Values []Customer `json:"data"`
+type CustomerList = List[Customer]
+
+// CustomerListParams filters a list of customers. All fields are optional.
+type CustomerListParams struct {
+	ListParams
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
 }
 
 // Create creates a new customer
 // For more details see https://developers.paystack.co/v1.0/reference#create-customer
 func (s *CustomerService) Create(customer *Customer) (*Customer, error) {
+	return s.CreateContext(context.Background(), customer)
+}
+
+// CreateContext is like Create but uses ctx for the request.
+func (s *CustomerService) CreateContext(ctx context.Context, customer *Customer) (*Customer, error) {
 	u := fmt.Sprintf("/customer")
 	cust := &Customer{}
-	err := s.client.Call("POST", u, customer, cust)
+	err := s.client.call(ctx, "Customer.Create", "POST", u, customer, cust)
 
 	return cust, err
 }
@@ -47,9 +64,14 @@ func (s *CustomerService) Create(customer *Customer) (*Customer, error) {
 // Update updates a customer's properties.
 // For more details see https://developers.paystack.co/v1.0/reference#update-customer
 func (s *CustomerService) Update(customer *Customer) (*Customer, error) {
+	return s.UpdateContext(context.Background(), customer)
+}
+
+// UpdateContext is like Update but uses ctx for the request.
+func (s *CustomerService) UpdateContext(ctx context.Context, customer *Customer) (*Customer, error) {
 	u := fmt.Sprintf("customer/%d", customer.ID)
 	cust := &Customer{}
-	err := s.client.Call("PUT", u, customer, cust)
+	err := s.client.call(ctx, "Customer.Update", "PUT", u, customer, cust)
 
 	return cust, err
 }
@@ -57,9 +79,14 @@ func (s *CustomerService) Update(customer *Customer) (*Customer, error) {
 // Get returns the details of a customer.
 // For more details see https://paystack.com/docs/api/#customer-fetch
 func (s *CustomerService) Get(customerCode string) (*Customer, error) {
+	return s.GetContext(context.Background(), customerCode)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *CustomerService) GetContext(ctx context.Context, customerCode string) (*Customer, error) {
 	u := fmt.Sprintf("/customer/%s", customerCode)
 	cust := &Customer{}
-	err := s.client.Call("GET", u, nil, cust)
+	err := s.client.call(ctx, "Customer.Get", "GET", u, nil, cust)
 
 	return cust, err
 }
@@ -67,21 +94,88 @@ func (s *CustomerService) Get(customerCode string) (*Customer, error) {
 // List returns a list of customers.
 // For more details see https://developers.paystack.co/v1.0/reference#list-customers
 func (s *CustomerService) List() (*CustomerList, error) {
-	return s.ListN(10, 0)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *CustomerService) ListContext(ctx context.Context) (*CustomerList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // ListN returns a list of customers
 // For more details see https://developers.paystack.co/v1.0/reference#list-customers
 func (s *CustomerService) ListN(count, offset int) (*CustomerList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *CustomerService) ListNContext(ctx context.Context, count, offset int) (*CustomerList, error) {
 	u := paginateURL("/customer", count, offset)
 	cust := &CustomerList{}
-	err := s.client.Call("GET", u, nil, cust)
+	err := s.client.call(ctx, "Customer.ListN", "GET", u, nil, cust)
 	return cust, err
 }
 
+// Iter returns an iterator over all customers, fetching perPage of them at a time.
+func (s *CustomerService) Iter(ctx context.Context, perPage int) *Iterator[Customer] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Customer], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
+
+// ListWithParams returns a list of customers matching params
+// For more details see https://paystack.com/docs/api/customer/#list
+func (s *CustomerService) ListWithParams(params *CustomerListParams) (*CustomerList, error) {
+	return s.ListWithParamsContext(context.Background(), params)
+}
+
+// ListWithParamsContext is like ListWithParams but uses ctx for the request.
+func (s *CustomerService) ListWithParamsContext(ctx context.Context, params *CustomerListParams) (*CustomerList, error) {
+	u := filterURL("/customer", params)
+	cust := &CustomerList{}
+	err := s.client.call(ctx, "Customer.ListWithParams", "GET", u, nil, cust)
+	return cust, err
+}
+
+// IterWithParams returns an iterator over all customers matching params,
+// fetching params.PerPage of them at a time. params.Page is ignored.
+func (s *CustomerService) IterWithParams(ctx context.Context, params CustomerListParams) *Iterator[Customer] {
+	return newIterator(ctx, params.PerPage, func(ctx context.Context, perPage, page int) (*List[Customer], error) {
+		params.ListParams = ListParams{PerPage: perPage, Page: page}
+		return s.ListWithParamsContext(ctx, &params)
+	})
+}
+
+// ListCursor returns the page of customers selected by cursor, using cursor pagination
+// For more details see https://paystack.com/docs/api/pagination/#cursor-pagination
+func (s *CustomerService) ListCursor(count int, cursor Cursor) (*CustomerList, error) {
+	return s.ListCursorContext(context.Background(), count, cursor)
+}
+
+// ListCursorContext is like ListCursor but uses ctx for the request.
+func (s *CustomerService) ListCursorContext(ctx context.Context, count int, cursor Cursor) (*CustomerList, error) {
+	u := cursorURL("/customer", count, cursor)
+	cust := &CustomerList{}
+	err := s.client.call(ctx, "Customer.ListCursor", "GET", u, nil, cust)
+	return cust, err
+}
+
+// IterCursor returns an iterator over all customers using cursor pagination,
+// which stays consistent while new customers are being created.
+func (s *CustomerService) IterCursor(ctx context.Context, perPage int) *Iterator[Customer] {
+	return newCursorIterator(ctx, perPage, func(ctx context.Context, perPage int, cursor Cursor) (*List[Customer], error) {
+		return s.ListCursorContext(ctx, perPage, cursor)
+	})
+}
+
 // SetRiskAction can be used to either whitelist or blacklist a customer
 // For more details see https://developers.paystack.co/v1.0/reference#whiteblacklist-customer
 func (s *CustomerService) SetRiskAction(customerCode, riskAction string) (*Customer, error) {
+	return s.SetRiskActionContext(context.Background(), customerCode, riskAction)
+}
+
+// SetRiskActionContext is like SetRiskAction but uses ctx for the request.
+func (s *CustomerService) SetRiskActionContext(ctx context.Context, customerCode, riskAction string) (*Customer, error) {
 	reqBody := struct {
 		Customer    string `json:"customer"`
 		Risk_action string `json:"risk_action"`
@@ -90,7 +184,7 @@ func (s *CustomerService) SetRiskAction(customerCode, riskAction string) (*Custo
 		Risk_action: riskAction,
 	}
 	cust := &Customer{}
-	err := s.client.Call("POST", "/customer/set_risk_action", reqBody, cust)
+	err := s.client.call(ctx, "Customer.SetRiskAction", "POST", "/customer/set_risk_action", reqBody, cust)
 
 	return cust, err
 }
@@ -98,11 +192,16 @@ func (s *CustomerService) SetRiskAction(customerCode, riskAction string) (*Custo
 // DeactivateAuthorization deactivates an authorization
 // For more details see https://developers.paystack.co/v1.0/reference#deactivate-authorization
 func (s *CustomerService) DeactivateAuthorization(authorizationCode string) (*Response, error) {
+	return s.DeactivateAuthorizationContext(context.Background(), authorizationCode)
+}
+
+// DeactivateAuthorizationContext is like DeactivateAuthorization but uses ctx for the request.
+func (s *CustomerService) DeactivateAuthorizationContext(ctx context.Context, authorizationCode string) (*Response, error) {
 	params := url.Values{}
 	params.Add("authorization_code", authorizationCode)
 
 	resp := &Response{}
-	err := s.client.Call("POST", "/customer/deactivate_authorization", params, resp)
+	err := s.client.call(ctx, "Customer.DeactivateAuthorization", "POST", "/customer/deactivate_authorization", params, resp)
 
 	return resp, err
 }
@@ -5,6 +5,8 @@ import (
 )
 
 func TestCustomerCRUD(t *testing.T) {
+	useClient(t)
+
 	cust := &Customer{
 		FirstName: "User123",
 		LastName:  "AdminUser",
@@ -47,6 +49,8 @@ func TestCustomerCRUD(t *testing.T) {
 }
 
 func TestCustomerRiskAction(t *testing.T) {
+	useClient(t)
+
 	cust := &Customer{
 		FirstName: "User123",
 		LastName:  "AdminUser",
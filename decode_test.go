@@ -0,0 +1,133 @@
+package paystack
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+const transactionListBody = `{"status":true,"message":"Transactions retrieved","data":[` +
+	`{"id":1,"reference":"ref1","amount":5000,"status":"success","fees":75,"paid_at":"2024-01-02T10:00:00.000Z","channel":"card","currency":"NGN","customer":{"id":7,"email":"a@b.com"},"authorization":{"authorization_code":"AUTH_1","last4":"4081","reusable":true}},` +
+	`{"id":2,"reference":"ref2","amount":2500,"status":"abandoned","fees":0,"paid_at":null,"channel":"bank","currency":"NGN","customer":{"id":8,"email":"c@d.com"},"authorization":{}}` +
+	`],"meta":{"total":42,"skipped":0,"perPage":2,"page":1,"pageCount":21}}`
+
+const transactionBody = `{"status":true,"message":"Verification successful","data":` +
+	`{"id":1,"reference":"ref1","amount":5000,"status":"success","fees":75,"paid_at":"2024-01-02T10:00:00.000Z","channel":"card","currency":"NGN","metadata":{"cart_id":3},"customer":{"id":7,"email":"a@b.com"}}}`
+
+func TestDecodeList(t *testing.T) {
+	c := NewClient("sk_test_key", nil)
+	c.LoggingEnabled = false
+
+	txns := &TransactionList{}
+	if err := c.decodeResponse(newTestResponse(200, transactionListBody), txns); err != nil {
+		t.Fatal(err)
+	}
+	if txns.Meta.Total != 42 || txns.Meta.PageCount != 21 {
+		t.Errorf("Expected list meta to be decoded, got %+v", txns.Meta)
+	}
+	if len(txns.Values) != 2 || txns.Values[0].Customer.Email != "a@b.com" {
+		t.Errorf("Expected 2 transactions, got %+v", txns.Values)
+	}
+}
+
+func TestDecodeResource(t *testing.T) {
+	c := NewClient("sk_test_key", nil)
+	c.LoggingEnabled = false
+
+	txn := &Transaction{}
+	if err := c.decodeResponse(newTestResponse(200, transactionBody), txn); err != nil {
+		t.Fatal(err)
+	}
+	if txn.Fees != NewMoney(75, "NGN") || txn.PaidAt.IsZero() {
+		t.Errorf("Expected fees and paid_at to be decoded, got %+v", txn)
+	}
+
+	resp := Response{}
+	if err := c.decodeResponse(newTestResponse(200, transactionBody), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp["reference"] != "ref1" {
+		t.Errorf("Expected response map to hold data, got %v", resp)
+	}
+
+	resp = Response{}
+	if err := c.decodeResponse(newTestResponse(200, transactionListBody), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := resp["meta"]; !ok {
+		t.Errorf("Expected response map of list to hold the whole envelope, got %v", resp)
+	}
+}
+
+func TestDecodeStatusFalse(t *testing.T) {
+	c := NewClient("sk_test_key", nil)
+	c.LoggingEnabled = false
+
+	err := c.decodeResponse(newTestResponse(200, `{"status":false,"message":"Invalid reference"}`), &Transaction{})
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Message != "Invalid reference" {
+		t.Errorf("Expected *APIError for status false, got %v", err)
+	}
+}
+
+// legacyDecode is the map + mapstructure decoding used before Envelope
+func legacyDecode(body []byte, v interface{}) error {
+	var resp Response
+	json.Unmarshal(body, &resp)
+	config := &mapstructure.DecoderConfig{Result: v, TagName: "json", WeaklyTypedInput: true}
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return err
+	}
+	if data, ok := resp["data"].(map[string]interface{}); ok {
+		return decoder.Decode(data)
+	}
+	return decoder.Decode(resp)
+}
+
+func BenchmarkDecodeList(b *testing.B) {
+	c := NewClient("sk_test_key", nil)
+	c.LoggingEnabled = false
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.decodeResponse(newTestResponse(200, transactionListBody), &TransactionList{})
+	}
+}
+
+func BenchmarkDecodeListLegacy(b *testing.B) {
+	body := []byte(transactionListBody)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		legacyDecode(body, &TransactionList{})
+	}
+}
+
+func BenchmarkDecodeResource(b *testing.B) {
+	c := NewClient("sk_test_key", nil)
+	c.LoggingEnabled = false
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.decodeResponse(newTestResponse(200, transactionBody), &Transaction{})
+	}
+}
+
+func BenchmarkDecodeResourceLegacy(b *testing.B) {
+	body := []byte(transactionBody)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		legacyDecode(body, &Transaction{})
+	}
+}
+
+func newTestResponse(status int, body string) *http.Response {
+	req, _ := http.NewRequest("GET", "https://api.paystack.co/transaction", nil)
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{},
+		Request:    req,
+	}
+}
@@ -1,6 +1,7 @@
 package paystack
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -16,20 +17,32 @@ type DedicatedVirtualAccount struct {
 	Metadata      interface{} `json:"metadata,omitempty"`
 	Active        bool        `json:"active,omitempty"`
 	Id            int         `json:"id,omitempty"`
-	CreatedAt     string      `json:"created_at,omitempty"`
-	UpdatedAt     string      `json:"updated_at,omitempty"`
+	CreatedAt     Timestamp   `json:"created_at,omitzero"`
+	UpdatedAt     Timestamp   `json:"updated_at,omitzero"`
 	Assignment    Assignment  `json:"assignment,omitempty"`
 	Customer      Customer    `json:"customer,omitempty"`
 	SplitConfig   Split       `json:"split_config,omitempty"`
 }
 
+// UnmarshalJSON decodes a, accepting camelCase or snake_case timestamp keys.
+func (a *DedicatedVirtualAccount) UnmarshalJSON(data []byte) error {
+	type dedicatedVirtualAccount DedicatedVirtualAccount
+	return unmarshalTimestamps(data, (*dedicatedVirtualAccount)(a))
+}
+
 type Assignment struct {
-	Integration  int    `json:"integration,omitempty"`
-	AssigneeId   int    `json:"assignee_id,omitempty"`
-	AssigneeType string `json:"assignee_type,omitempty"`
-	Expired      bool   `json:"expired,omitempty"`
-	AccountType  string `json:"account_type,omitempty"`
-	AssignedAt   string `json:"assigned_at,omitempty"`
+	Integration  int       `json:"integration,omitempty"`
+	AssigneeId   int       `json:"assignee_id,omitempty"`
+	AssigneeType string    `json:"assignee_type,omitempty"`
+	Expired      bool      `json:"expired,omitempty"`
+	AccountType  string    `json:"account_type,omitempty"`
+	AssignedAt   Timestamp `json:"assigned_at,omitzero"`
+}
+
+// UnmarshalJSON decodes a, accepting camelCase or snake_case timestamp keys.
+func (a *Assignment) UnmarshalJSON(data []byte) error {
+	type assignment Assignment
+	return unmarshalTimestamps(data, (*assignment)(a))
 }
 
 type DedicatedVirtualAccountRequest struct {
@@ -58,14 +71,11 @@ type AssignDVARequest struct {
 }
 
 // DVAList is a list object for Dedicated Virtual Accounts.
-type DVAList struct {
-	Meta   ListMeta
-	Values []DedicatedVirtualAccount `json:"data"`
-}
+type DVAList = List[DedicatedVirtualAccount]
 
 // Filter for retrieving DVA list. All fields are optional
 type DVAListFilter struct {
-	Active       bool   `json:"active,omitempty"`
+	Active       *bool  `json:"active,omitempty"` // nil lists active and inactive accounts
 	Currency     string `json:"currency,omitempty"`
 	ProviderSlug string `json:"provider_slug,omitempty"`
 	BankId       string `json:"bank_id,omitempty"`
@@ -79,7 +89,7 @@ type RequeryDVARequest struct {
 }
 
 type DVATransactionSplitRequest struct {
-	Customer      int `json:"customer,omitempty"`   // Customer ID or code
+	Customer      int    `json:"customer,omitempty"`   // Customer ID or code
 	SubAccount    string `json:"subaccount,omitempty"` // Subaccount code of the account you want to split the transaction with
 	SplitCode     string `json:"split_code,omitempty"` // Split code consisting of the lists of accounts you want to split the transaction with
 	PreferredBank string `json:"preferred_bank,omitempty"`
@@ -95,88 +105,146 @@ type BankProvider struct {
 // Create a dedicated virtual account for an existing customer.
 // For more details see https://paystack.com/docs/api/dedicated-virtual-account/#create
 func (s *DedicatedVirtualAccountService) Create(request *DedicatedVirtualAccountRequest) (*DedicatedVirtualAccount, error) {
+	return s.CreateContext(context.Background(), request)
+}
+
+// CreateContext is like Create but uses ctx for the request.
+func (s *DedicatedVirtualAccountService) CreateContext(ctx context.Context, request *DedicatedVirtualAccountRequest) (*DedicatedVirtualAccount, error) {
 	url := "/dedicated_account"
 	dva := &DedicatedVirtualAccount{}
-	err := s.client.Call("POST", url, request, dva)
+	err := s.client.call(ctx, "DedicatedVirtualAccount.Create", "POST", url, request, dva)
 	return dva, err
 }
 
 // Create a customer, validate the customer, and assign a DVA to the customer. The process is asynchronous - listen for response using webhooks.
 // For more details see https://paystack.com/docs/api/dedicated-virtual-account/#assign
 func (s *DedicatedVirtualAccountService) Assign(request *AssignDVARequest) (*DedicatedVirtualAccount, error) {
+	return s.AssignContext(context.Background(), request)
+}
+
+// AssignContext is like Assign but uses ctx for the request.
+func (s *DedicatedVirtualAccountService) AssignContext(ctx context.Context, request *AssignDVARequest) (*DedicatedVirtualAccount, error) {
 	url := "/dedicated_account"
 	dva := &DedicatedVirtualAccount{}
-	err := s.client.Call("POST", url, request, dva)
+	err := s.client.call(ctx, "DedicatedVirtualAccount.Assign", "POST", url, request, dva)
 	return dva, err
 }
 
 // List dedicated virtual accounts available on your integration.
 // For more details see https://paystack.com/docs/api/dedicated-virtual-account/#list
 func (s *DedicatedVirtualAccountService) List(filter *DVAListFilter) (*DVAList, error) {
-	return s.ListN(filter, 10, 1)
+	return s.ListContext(context.Background(), filter)
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *DedicatedVirtualAccountService) ListContext(ctx context.Context, filter *DVAListFilter) (*DVAList, error) {
+	return s.ListNContext(ctx, filter, 10, 1)
 }
 
 // List dedicated virtual accounts available on your integration.
 // For more details see https://paystack.com/docs/api/dedicated-virtual-account/#list
 func (s *DedicatedVirtualAccountService) ListN(filter *DVAListFilter, count, offset int) (*DVAList, error) {
-	url := paginateURL("/dedicated_account", count, offset)
+	return s.ListNContext(context.Background(), filter, count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *DedicatedVirtualAccountService) ListNContext(ctx context.Context, filter *DVAListFilter, count, offset int) (*DVAList, error) {
+	url := listURL("/dedicated_account", count, offset, filter)
 	dvaList := &DVAList{}
-	err := s.client.Call("GET", url, nil, dvaList)
+	err := s.client.call(ctx, "DedicatedVirtualAccount.ListN", "GET", url, nil, dvaList)
 	return dvaList, err
 }
 
+// Iter returns an iterator over all dedicated virtual accounts matching filter,
+// fetching perPage of them at a time.
+func (s *DedicatedVirtualAccountService) Iter(ctx context.Context, filter *DVAListFilter, perPage int) *Iterator[DedicatedVirtualAccount] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[DedicatedVirtualAccount], error) {
+		return s.ListNContext(ctx, filter, perPage, page)
+	})
+}
+
 // Get details of a dedicated virtual account on your integration.
 // For more details see https://paystack.com/docs/api/dedicated-virtual-account/#fetch
 func (s *DedicatedVirtualAccountService) Get(id int) (*DedicatedVirtualAccount, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *DedicatedVirtualAccountService) GetContext(ctx context.Context, id int) (*DedicatedVirtualAccount, error) {
 	url := fmt.Sprintf("/dedicated_account/%d", id)
 	dva := &DedicatedVirtualAccount{}
-	err := s.client.Call("GET", url, nil, dva)
+	err := s.client.call(ctx, "DedicatedVirtualAccount.Get", "GET", url, nil, dva)
 	return dva, err
 }
 
 // Requery Dedicated Virtual Account for new transactions.
 // For more details see https://paystack.com/docs/api/dedicated-virtual-account/#requery
 func (s *DedicatedVirtualAccountService) Requery(request *RequeryDVARequest) (*DedicatedVirtualAccount, error) {
+	return s.RequeryContext(context.Background(), request)
+}
+
+// RequeryContext is like Requery but uses ctx for the request.
+func (s *DedicatedVirtualAccountService) RequeryContext(ctx context.Context, request *RequeryDVARequest) (*DedicatedVirtualAccount, error) {
 	url := fmt.Sprintf("/dedicated_account/requery?account_number=%s&provider_slug=%s&date=%s", request.AccountNumber, request.ProviderSlug, request.Date)
 	dva := &DedicatedVirtualAccount{}
-	err := s.client.Call("GET", url, nil, dva)
+	err := s.client.call(ctx, "DedicatedVirtualAccount.Requery", "GET", url, nil, dva)
 	return dva, err
 }
 
 // Deactivate a dedicated virtual account on your integration.
 // For more details see https://paystack.com/docs/api/dedicated-virtual-account/#deactivate
 func (s *DedicatedVirtualAccountService) Deactivate(id int) (*DedicatedVirtualAccount, error) {
+	return s.DeactivateContext(context.Background(), id)
+}
+
+// DeactivateContext is like Deactivate but uses ctx for the request.
+func (s *DedicatedVirtualAccountService) DeactivateContext(ctx context.Context, id int) (*DedicatedVirtualAccount, error) {
 	url := fmt.Sprintf("/dedicated_account/:%d", id)
 	dva := &DedicatedVirtualAccount{}
-	err := s.client.Call("DELETE", url, nil, dva)
+	err := s.client.call(ctx, "DedicatedVirtualAccount.Deactivate", "DELETE", url, nil, dva)
 	return dva, err
 }
 
 // Split a dedicated virtual account transaction with one or more accounts.
 // For more details see https://paystack.com/docs/api/dedicated-virtual-account/#add-split
 func (s *DedicatedVirtualAccountService) Split(request *DVATransactionSplitRequest) (*DedicatedVirtualAccount, error) {
+	return s.SplitContext(context.Background(), request)
+}
+
+// SplitContext is like Split but uses ctx for the request.
+func (s *DedicatedVirtualAccountService) SplitContext(ctx context.Context, request *DVATransactionSplitRequest) (*DedicatedVirtualAccount, error) {
 	url := "/dedicated_account"
 	dva := &DedicatedVirtualAccount{}
-	err := s.client.Call("POST", url, request, dva)
+	err := s.client.call(ctx, "DedicatedVirtualAccount.Split", "POST", url, request, dva)
 	return dva, err
 }
 
 // Remove split payments for transactions on a dedicated virtual account
 // For more details see https://paystack.com/docs/api/dedicated-virtual-account/#remove-split
 func (s *DedicatedVirtualAccountService) RemoveSplit(acct string) (*DedicatedVirtualAccount, error) {
+	return s.RemoveSplitContext(context.Background(), acct)
+}
+
+// RemoveSplitContext is like RemoveSplit but uses ctx for the request.
+func (s *DedicatedVirtualAccountService) RemoveSplitContext(ctx context.Context, acct string) (*DedicatedVirtualAccount, error) {
 	u := "/dedicated_account/split"
 	dva := &DedicatedVirtualAccount{}
 	req := url.Values{}
 	req.Add("account_number", acct)
-	err := s.client.Call("DELETE", u, req, dva)
+	err := s.client.call(ctx, "DedicatedVirtualAccount.RemoveSplit", "DELETE", u, req, dva)
 	return dva, err
 }
 
 // Get available bank providers for a dedicated virtual account
 // For more details see https://paystack.com/docs/api/dedicated-virtual-account/#providers
 func (s *DedicatedVirtualAccountService) GetBankProviders() ([]BankProvider, error) {
+	return s.GetBankProvidersContext(context.Background())
+}
+
+// GetBankProvidersContext is like GetBankProviders but uses ctx for the request.
+func (s *DedicatedVirtualAccountService) GetBankProvidersContext(ctx context.Context) ([]BankProvider, error) {
 	url := "/dedicated_account/available_providers"
 	providers := []BankProvider{}
-	err := s.client.Call("GET", url, nil, providers)
+	err := s.client.call(ctx, "DedicatedVirtualAccount.GetBankProviders", "GET", url, nil, &providers)
 	return providers, err
 }
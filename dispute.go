@@ -1,6 +1,7 @@
 package paystack
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -8,22 +9,34 @@ import (
 type DisputeService service
 
 type DisputeMessage struct {
-	Sender    string `json:"sender,omitempty"`
-	Body      string `json:"body,omitempty"`
-	Dispute   int    `json:"dispute,omitempty"`
-	Id        int    `json:"id,omitempty"`
-	IsDeleted int    `json:"is_deleted,omitempty"`
-	CreatedAt string `json:"createdAt,omitempty"`
-	UpdatedAt string `json:"updatedAt,omitempty"`
+	Sender    string    `json:"sender,omitempty"`
+	Body      string    `json:"body,omitempty"`
+	Dispute   int       `json:"dispute,omitempty"`
+	Id        int       `json:"id,omitempty"`
+	IsDeleted int       `json:"is_deleted,omitempty"`
+	CreatedAt Timestamp `json:"createdAt,omitzero"`
+	UpdatedAt Timestamp `json:"updatedAt,omitzero"`
+}
+
+// UnmarshalJSON decodes m, accepting camelCase or snake_case timestamp keys.
+func (m *DisputeMessage) UnmarshalJSON(data []byte) error {
+	type disputeMessage DisputeMessage
+	return unmarshalTimestamps(data, (*disputeMessage)(m))
 }
 
 type DisputeState struct {
-	Id        int    `json:"id,omitempty"`
-	Dispute   int    `json:"dispute,omitempty"`
-	Status    string `json:"status,omitempty"`
-	By        string `json:"by,omitempty"`
-	CreatedAt string `json:"createdAt,omitempty"`
-	UpdatedAt string `json:"updatedAt,omitempty"`
+	Id        int       `json:"id,omitempty"`
+	Dispute   int       `json:"dispute,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	By        string    `json:"by,omitempty"`
+	CreatedAt Timestamp `json:"createdAt,omitzero"`
+	UpdatedAt Timestamp `json:"updatedAt,omitzero"`
+}
+
+// UnmarshalJSON decodes s, accepting camelCase or snake_case timestamp keys.
+func (s *DisputeState) UnmarshalJSON(data []byte) error {
+	type disputeState DisputeState
+	return unmarshalTimestamps(data, (*disputeState)(s))
 }
 
 type Dispute struct {
@@ -32,7 +45,7 @@ type Dispute struct {
 	Bin                    string           `json:"bin,omitempty"` // Verify data type
 	TransactionReference   string           `json:"transaction_reference,omitempty"`
 	MerchantTransactionRef string           `json:"merchant_transaction_reference,omitempty"`
-	RefundAmount           int              `json:"refund_amount,omitempty"`
+	RefundAmount           Money            `json:"refund_amount,omitzero"`
 	Status                 string           `json:"status,omitempty"`
 	Domain                 string           `json:"domain,omitempty"`
 	Resolution             string           `json:"resolution,omitempty"`
@@ -43,35 +56,44 @@ type Dispute struct {
 	Integration            int              `json:"integration,omitempty"`
 	CreatedBy              string           `json:"created_by,omitempty"`
 	Evidence               DisputeEvidence  `json:"evidence,omitempty"`
-	ResolvedAt             string           `json:"resolvedAt,omitempty"`
-	CreatedAt              string           `json:"createdAt,omitempty"`
-	UpdatedAt              string           `json:"updatedAt,omitempty"`
-	DueAt                  string           `json:"dueAt,omitempty"`
+	ResolvedAt             Timestamp        `json:"resolvedAt,omitzero"`
+	CreatedAt              Timestamp        `json:"createdAt,omitzero"`
+	UpdatedAt              Timestamp        `json:"updatedAt,omitzero"`
+	DueAt                  Timestamp        `json:"dueAt,omitzero"`
 	Transaction            Transaction      `json:"transaction,omitempty"`
 	Messages               []DisputeMessage `json:"messages,omitempty"`
 	History                []DisputeState   `json:"history,omitempty"`
 }
 
-// DisputeList is a list object for disputes.
-type DisputeList struct {
-	Meta   ListMeta
-	Values []Dispute `json:"data"`
+// UnmarshalJSON decodes d, accepting camelCase or snake_case timestamp keys.
+func (d *Dispute) UnmarshalJSON(data []byte) error {
+	type dispute Dispute
+	return unmarshalTimestamps(data, (*dispute)(d))
 }
 
+// DisputeList is a list object for disputes.
+type DisputeList = List[Dispute]
+
 type DisputeEvidence struct {
-	CustomerEmail   string `json:"customer_email,omitempty"`
-	CustomerName    string `json:"customer_name,omitempty"`
-	CustomerPhone   string `json:"customer_phone,omitempty"`
-	ServiceDetails  string `json:"service_details,omitempty"`
-	DeliveryAddress string `json:"delivery_address,omitempty"`
-	Dispute         int    `json:"dispute,omitempty"` // Dispute ID
-	Id              int    `json:"id,omitempty"`      // Evidence ID
-	CreatedAt       string `json:"createdAt,omitempty"`
-	UpdatedAt       string `json:"updatedAt,omitempty"`
+	CustomerEmail   string    `json:"customer_email,omitempty"`
+	CustomerName    string    `json:"customer_name,omitempty"`
+	CustomerPhone   string    `json:"customer_phone,omitempty"`
+	ServiceDetails  string    `json:"service_details,omitempty"`
+	DeliveryAddress string    `json:"delivery_address,omitempty"`
+	Dispute         int       `json:"dispute,omitempty"` // Dispute ID
+	Id              int       `json:"id,omitempty"`      // Evidence ID
+	CreatedAt       Timestamp `json:"createdAt,omitzero"`
+	UpdatedAt       Timestamp `json:"updatedAt,omitzero"`
+}
+
+// UnmarshalJSON decodes e, accepting camelCase or snake_case timestamp keys.
+func (e *DisputeEvidence) UnmarshalJSON(data []byte) error {
+	type disputeEvidence DisputeEvidence
+	return unmarshalTimestamps(data, (*disputeEvidence)(e))
 }
 
 type UpdateDisputeRequest struct {
-	RefundAmount     int    `json:"refund_amount,omitempty"`
+	RefundAmount     Money  `json:"refund_amount,omitzero"`
 	UploadedFilename string `json:"uploaded_filename,omitempty"` // Optional
 }
 
@@ -87,7 +109,7 @@ type ResolveDisputeRequest struct {
 	Resolution       string `json:"resolution,omitempty"`
 	Message          string `json:"message,omitempty"`
 	UploadedFilename string `json:"uploaded_filename,omitempty"`
-	RefundAmount     int    `json:"refund_amount,omitempty"`
+	RefundAmount     Money  `json:"refund_amount,omitzero"`
 	Evidence         int    `json:"evidence,omitempty"` // Evidence id
 }
 
@@ -105,84 +127,143 @@ type Upload struct {
 }
 
 type Export struct {
-	Path      string `json:"path,omitempty"`
-	ExpiresAt string `json:"expiresAt,omitempty"`
+	Path      string    `json:"path,omitempty"`
+	ExpiresAt Timestamp `json:"expiresAt,omitzero"`
+}
+
+// UnmarshalJSON decodes e, accepting camelCase or snake_case timestamp keys.
+func (e *Export) UnmarshalJSON(data []byte) error {
+	type export Export
+	return unmarshalTimestamps(data, (*export)(e))
 }
 
 // List disputes filed against you.
 // For more details see https://paystack.com/docs/api/dispute/#list
 func (s *DisputeService) List(options *DisputeFilterOptions) (*DisputeList, error) {
-	return s.ListN(options, 10, 1)
+	return s.ListContext(context.Background(), options)
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *DisputeService) ListContext(ctx context.Context, options *DisputeFilterOptions) (*DisputeList, error) {
+	return s.ListNContext(ctx, options, 10, 1)
 }
 
 // List disputes filed against you.
 // For more details see https://paystack.com/docs/api/dispute/#list
 func (s *DisputeService) ListN(options *DisputeFilterOptions, count, offset int) (*DisputeList, error) {
-	url := paginateURL("/dispute", count, offset)
+	return s.ListNContext(context.Background(), options, count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *DisputeService) ListNContext(ctx context.Context, options *DisputeFilterOptions, count, offset int) (*DisputeList, error) {
+	url := listURL("/dispute", count, offset, options)
 	disputes := &DisputeList{}
-	err := s.client.Call("GET", url, options, disputes)
+	err := s.client.call(ctx, "Dispute.ListN", "GET", url, nil, disputes)
 	return disputes, err
 }
 
+// Iter returns an iterator over all disputes matching options,
+// fetching perPage of them at a time.
+func (s *DisputeService) Iter(ctx context.Context, options *DisputeFilterOptions, perPage int) *Iterator[Dispute] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Dispute], error) {
+		return s.ListNContext(ctx, options, perPage, page)
+	})
+}
+
 // Get details of Dispute with the specified id.
 // For more details see https://paystack.com/docs/api/dispute/#fetch
 func (s *DisputeService) Get(id int) (*Dispute, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *DisputeService) GetContext(ctx context.Context, id int) (*Dispute, error) {
 	url := fmt.Sprintf("/dispute/%d", id)
 	dispute := &Dispute{}
-	err := s.client.Call("GET", url, nil, dispute)
+	err := s.client.call(ctx, "Dispute.Get", "GET", url, nil, dispute)
 	return dispute, err
 }
 
 // Retrieve disputes for a particular transaction.
 // For more details see https://paystack.com/docs/api/dispute/#transaction
 func (s *DisputeService) ListTransactionDisputes(id int) (*Dispute, error) {
+	return s.ListTransactionDisputesContext(context.Background(), id)
+}
+
+// ListTransactionDisputesContext is like ListTransactionDisputes but uses ctx for the request.
+func (s *DisputeService) ListTransactionDisputesContext(ctx context.Context, id int) (*Dispute, error) {
 	url := fmt.Sprintf("/dispute/transaction/%d", id)
 	dispute := &Dispute{}
-	err := s.client.Call("GET", url, nil, dispute)
+	err := s.client.call(ctx, "Dispute.ListTransactionDisputes", "GET", url, nil, dispute)
 	return dispute, err
 }
 
 // Update details of a dispute on your integration.
 // For more details see https://paystack.com/docs/api/dispute/#update
 func (s *DisputeService) Update(id int, request *UpdateDisputeRequest) (*Dispute, error) {
+	return s.UpdateContext(context.Background(), id, request)
+}
+
+// UpdateContext is like Update but uses ctx for the request.
+func (s *DisputeService) UpdateContext(ctx context.Context, id int, request *UpdateDisputeRequest) (*Dispute, error) {
 	url := fmt.Sprintf("dispute/%d", id)
 	dispute := &Dispute{}
-	err := s.client.Call("PUT", url, request, dispute)
+	err := s.client.call(ctx, "Dispute.Update", "PUT", url, request, dispute)
 	return dispute, err
 }
 
 // Provide evidence for a dispute.
 // For more details see https://paystack.com/docs/api/dispute/#evidence
 func (s *DisputeService) AddDisputeEvidence(id int, request *AddDisputeEvidenceRequest) (*DisputeEvidence, error) {
+	return s.AddDisputeEvidenceContext(context.Background(), id, request)
+}
+
+// AddDisputeEvidenceContext is like AddDisputeEvidence but uses ctx for the request.
+func (s *DisputeService) AddDisputeEvidenceContext(ctx context.Context, id int, request *AddDisputeEvidenceRequest) (*DisputeEvidence, error) {
 	url := fmt.Sprintf("dispute/%d/evidence", id)
 	evidence := &DisputeEvidence{}
-	err := s.client.Call("POST", url, request, evidence)
+	err := s.client.call(ctx, "Dispute.AddDisputeEvidence", "POST", url, request, evidence)
 	return evidence, err
 }
 
 // Resolve a dispute on your integration.
 // For more details see https://paystack.com/docs/api/dispute/#resolve
 func (s *DisputeService) ResolveDispute(id int, request *ResolveDisputeRequest) (*Dispute, error) {
+	return s.ResolveDisputeContext(context.Background(), id, request)
+}
+
+// ResolveDisputeContext is like ResolveDispute but uses ctx for the request.
+func (s *DisputeService) ResolveDisputeContext(ctx context.Context, id int, request *ResolveDisputeRequest) (*Dispute, error) {
 	url := fmt.Sprintf("dispute/%d/resolve", id)
 	dispute := &Dispute{}
-	err := s.client.Call("PUT", url, request, dispute)
+	err := s.client.call(ctx, "Dispute.ResolveDispute", "PUT", url, request, dispute)
 	return dispute, err
 }
 
 // Retrieve signed upload URL for dispute evidence documents.
 // For more details see https://paystack.com/docs/api/dispute/#upload-url
 func (s *DisputeService) GetUploadURL(id int, uploadFilename string) (*Upload, error) {
+	return s.GetUploadURLContext(context.Background(), id, uploadFilename)
+}
+
+// GetUploadURLContext is like GetUploadURL but uses ctx for the request.
+func (s *DisputeService) GetUploadURLContext(ctx context.Context, id int, uploadFilename string) (*Upload, error) {
 	url := fmt.Sprintf("dispute/:%d/upload_url?upload_filename=%s", id, uploadFilename)
 	upload := &Upload{}
-	err := s.client.Call("GET", url, nil, upload)
+	err := s.client.call(ctx, "Dispute.GetUploadURL", "GET", url, nil, upload)
 	return upload, err
 }
 
 // Export disputes available on your integration.
 // For more details see https://paystack.com/docs/api/dispute/#export
 func (s *DisputeService) Export(options *DisputeFilterOptions) (*Export, error) {
-	url := "dispute/export"
+	return s.ExportContext(context.Background(), options)
+}
+
+// ExportContext is like Export but uses ctx for the request.
+func (s *DisputeService) ExportContext(ctx context.Context, options *DisputeFilterOptions) (*Export, error) {
+	url := filterURL("/dispute/export", options)
 	export := &Export{}
-	err := s.client.Call("GET", url, options, export)
+	err := s.client.call(ctx, "Dispute.Export", "GET", url, nil, export)
 	return export, err
 }
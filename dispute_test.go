@@ -3,6 +3,8 @@ package paystack
 import "testing"
 
 func TestDisputeService(t *testing.T) {
+	useClient(t)
+
 	// retrieve the dispute list
 	options := &DisputeFilterOptions{}
 	disputes, err := c.Dispute.List(options)
@@ -31,7 +33,7 @@ func TestDisputeService(t *testing.T) {
 	}
 
 	// Test UPDATE Dispute
-	newRefundAmount := 500000
+	newRefundAmount := NewMoney(500000, "NGN")
 	update := &UpdateDisputeRequest{
 		RefundAmount: newRefundAmount,
 	}
@@ -39,7 +41,7 @@ func TestDisputeService(t *testing.T) {
 	if err != nil {
 		t.Errorf("Failed to UPDATE Dispute: %v", err)
 	}
-	if updatedDispute.RefundAmount != newRefundAmount {
+	if updatedDispute.RefundAmount.Amount != newRefundAmount.Amount {
 		t.Errorf("Expected updated refund amount to be %v, got %v", newRefundAmount, updatedDispute.RefundAmount)
 	}
 
@@ -82,7 +84,7 @@ func TestDisputeService(t *testing.T) {
 		Resolution: "merchant-accepted",
         Message: "Merchant accepted", 
         UploadedFilename: "qesp8a4df1xejihd9x5q", 
-        RefundAmount: 300000, 
+        RefundAmount: NewMoney(300000, "NGN"), 
 	}
 	resolvedDispute, err := c.Dispute.ResolveDispute(dispute1.Id, request)
 	if err != nil {
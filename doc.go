@@ -30,7 +30,7 @@ Usage:
 	req := &TransferRequest{
 		Source:    "balance",
 		Reason:    "Delivery pickup",
-		Amount:    30,
+		Amount:    paystack.NewMoney(30, "NGN"),
 		Recipient: recipient1.RecipientCode,
 	}
 
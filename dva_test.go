@@ -3,6 +3,8 @@ package paystack
 import "testing"
 
 func TestDedicatedVirtualAccount(t *testing.T) {
+	useClient(t)
+
 	cust := &Customer{
 		FirstName: "User123",
 		LastName:  "AdminUser",
@@ -77,8 +79,8 @@ func TestDedicatedVirtualAccount(t *testing.T) {
 
 	// Test REQUERY
 	req := &RequeryDVARequest{
-		AccountNumber: "1234567890",
-		ProviderSlug:  "example-provider",
+		AccountNumber: dva1.AccountNumber,
+		ProviderSlug:  "test-bank",
 		Date:          "2023-05-30",
 	}
 	_, err = c.DedicatedVirtualAccount.Requery(req)
@@ -87,10 +89,19 @@ func TestDedicatedVirtualAccount(t *testing.T) {
 	}
 
 	// Test SPLIT
+	subAccount, err := c.SubAccount.Create(&SubAccount{
+		BusinessName:     "DVA Vendor",
+		SettlementBank:   "044",
+		AccountNumber:    "0193274682",
+		PercentageCharge: 10,
+	})
+	if err != nil {
+		t.Errorf("CREATE SubAccount returned error: %v", err)
+	}
 	splitRequest := &DVATransactionSplitRequest{
-		Customer: 481193,
+		Customer:      customer1.ID,
 		PreferredBank: "wema-bank",
-		SplitCode: "SPL_e7jnRLtzla",
+		SubAccount:    subAccount.SubAccountCode,
 	}
 	dva2, err := c.DedicatedVirtualAccount.Split(splitRequest)
 	if err != nil {
@@ -2,9 +2,28 @@ package paystack
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors classifying an *APIError. Use errors.Is to test for them:
+//
+//	if errors.Is(err, paystack.ErrDuplicateReference) { ... }
+//
+// A single error may match more than one sentinel; a duplicate reference is
+// also a validation error.
+var (
+	ErrUnauthorized        = errors.New("paystack: unauthorized")
+	ErrNotFound            = errors.New("paystack: not found")
+	ErrRateLimited         = errors.New("paystack: rate limited")
+	ErrValidation          = errors.New("paystack: validation failed")
+	ErrDuplicateReference  = errors.New("paystack: duplicate reference")
+	ErrInsufficientBalance = errors.New("paystack: insufficient balance")
+	ErrServer              = errors.New("paystack: server error")
 )
 
 // APIError includes the response from the Paystack API and some HTTP request info
@@ -18,26 +37,132 @@ type APIError struct {
 
 // APIError supports the error interface
 func (aerr *APIError) Error() string {
-	ret, _ := json.Marshal(aerr)
-	return string(ret)
+	msg := aerr.Message
+	if msg == "" {
+		msg = http.StatusText(aerr.HTTPStatusCode)
+	}
+	s := fmt.Sprintf("paystack: %s (HTTP %d)", msg, aerr.HTTPStatusCode)
+
+	fields := aerr.FieldErrors()
+	if len(fields) == 0 {
+		return s
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		names[i] = name + ": " + strings.Join(fields[name], ", ")
+	}
+	return s + ": " + strings.Join(names, "; ")
+}
+
+// Is reports whether the error belongs to the class of target,
+// one of the Err* sentinels of this package.
+func (aerr *APIError) Is(target error) bool {
+	for _, class := range aerr.classes() {
+		if class == target {
+			return true
+		}
+	}
+	return false
+}
+
+// classes returns the sentinel errors aerr belongs to
+func (aerr *APIError) classes() []error {
+	var classes []error
+	msg := strings.ToLower(aerr.Details.Message)
+	code := strings.ToLower(aerr.Details.Code)
+
+	switch {
+	case aerr.HTTPStatusCode == http.StatusUnauthorized:
+		classes = append(classes, ErrUnauthorized)
+	case aerr.HTTPStatusCode == http.StatusNotFound:
+		classes = append(classes, ErrNotFound)
+	case aerr.HTTPStatusCode == http.StatusTooManyRequests:
+		classes = append(classes, ErrRateLimited)
+	case aerr.HTTPStatusCode >= 500:
+		classes = append(classes, ErrServer)
+	case aerr.HTTPStatusCode == http.StatusBadRequest,
+		aerr.HTTPStatusCode == http.StatusUnprocessableEntity,
+		strings.Contains(aerr.Details.Type, "validation"),
+		len(aerr.Details.Errors) > 0:
+		classes = append(classes, ErrValidation)
+	}
+
+	if strings.Contains(msg, "duplicate") && strings.Contains(msg, "reference") ||
+		strings.Contains(code, "duplicate_reference") {
+		classes = append(classes, ErrDuplicateReference)
+	}
+	if strings.Contains(msg, "balance is not enough") || strings.Contains(msg, "insufficient balance") ||
+		strings.Contains(code, "insufficient_balance") {
+		classes = append(classes, ErrInsufficientBalance)
+	}
+	return classes
+}
+
+// FieldErrors returns the validation messages of the error keyed by field
+// name, taken from the "errors" object of the Paystack response.
+func (aerr *APIError) FieldErrors() map[string][]string {
+	if len(aerr.Details.Errors) == 0 {
+		return nil
+	}
+	fields := make(map[string][]string, len(aerr.Details.Errors))
+	for field, v := range aerr.Details.Errors {
+		fields[field] = fieldMessages(v)
+	}
+	return fields
+}
+
+// fieldMessages flattens the shapes Paystack uses for a field's errors:
+// a string, a list of strings or a list of {"rule", "message"} objects
+func fieldMessages(v interface{}) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var msgs []string
+		for _, e := range t {
+			msgs = append(msgs, fieldMessages(e)...)
+		}
+		return msgs
+	case map[string]interface{}:
+		if msg, ok := t["message"].(string); ok {
+			return []string{msg}
+		}
+	}
+	b, _ := json.Marshal(v)
+	return []string{string(b)}
 }
 
 // ErrorResponse represents an error response from the Paystack API server
 type ErrorResponse struct {
 	Status  bool                   `json:"status,omitempty"`
 	Message string                 `json:"message,omitempty"`
+	Type    string                 `json:"type,omitempty"`
+	Code    string                 `json:"code,omitempty"`
 	Errors  map[string]interface{} `json:"errors,omitempty"`
 }
 
-func newAPIError(resp *http.Response) *APIError {
-	p, _ := ioutil.ReadAll(resp.Body)
-
-	var paystackErrorResp ErrorResponse
-	_ = json.Unmarshal(p, &paystackErrorResp)
-	return &APIError{
+// newAPIError builds an *APIError from resp and its already-read body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	// errors is not always an object, and must not spoil the rest of the body
+	var raw struct {
+		ErrorResponse
+		Errors json.RawMessage `json:"errors,omitempty"`
+	}
+	_ = json.Unmarshal(body, &raw)
+	paystackErrorResp := raw.ErrorResponse
+	_ = json.Unmarshal(raw.Errors, &paystackErrorResp.Errors)
+	aerr := &APIError{
+		Message:        paystackErrorResp.Message,
 		HTTPStatusCode: resp.StatusCode,
 		Header:         resp.Header,
 		Details:        paystackErrorResp,
-		URL:            resp.Request.URL,
 	}
+	if resp.Request != nil {
+		aerr.URL = resp.Request.URL
+	}
+	return aerr
 }
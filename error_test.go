@@ -0,0 +1,110 @@
+package paystack
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorClassification(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		is     []error
+		isNot  []error
+	}{
+		{401, `{"status":false,"message":"Invalid key"}`, []error{ErrUnauthorized}, []error{ErrValidation}},
+		{404, `{"status":false,"message":"Transaction reference not found"}`, []error{ErrNotFound}, []error{ErrDuplicateReference}},
+		{429, `{"status":false,"message":"Too many requests"}`, []error{ErrRateLimited}, nil},
+		{502, `<html>Bad Gateway</html>`, []error{ErrServer}, nil},
+		{400, `{"status":false,"message":"Duplicate Transaction Reference"}`, []error{ErrValidation, ErrDuplicateReference}, []error{ErrServer}},
+		{400, `{"status":false,"message":"Your balance is not enough to fulfil this request"}`, []error{ErrInsufficientBalance}, nil},
+		{200, `{"status":false,"message":"Charge attempted"}`, nil, []error{ErrValidation, ErrServer}},
+	}
+
+	for _, tc := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+			w.Write([]byte(tc.body))
+		}))
+		client := NewClient("sk_test_key", nil)
+		client.baseURL, _ = url.Parse(srv.URL)
+		client.LoggingEnabled = false
+
+		_, err := client.Transaction.Verify("ref")
+		srv.Close()
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != tc.status {
+			t.Errorf("%d %s: expected *APIError with status %d, got %v", tc.status, tc.body, tc.status, err)
+			continue
+		}
+		for _, target := range tc.is {
+			if !errors.Is(err, target) {
+				t.Errorf("%d %s: expected errors.Is(%v)", tc.status, tc.body, target)
+			}
+		}
+		for _, target := range tc.isNot {
+			if errors.Is(err, target) {
+				t.Errorf("%d %s: expected not errors.Is(%v)", tc.status, tc.body, target)
+			}
+		}
+	}
+}
+
+func TestAPIErrorFieldErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":false,"message":"Invalid params","errors":{"email":[{"rule":"email","message":"Email is invalid"}],"amount":["Amount is required"]}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("sk_test_key", nil)
+	client.baseURL, _ = url.Parse(srv.URL)
+	client.LoggingEnabled = false
+
+	_, err := client.Transaction.Initialize(&TransactionRequest{Email: "nope"})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+
+	var apiErr *APIError
+	errors.As(err, &apiErr)
+	if apiErr.Message != "Invalid params" || apiErr.Details.Message != "Invalid params" {
+		t.Errorf("Expected Paystack message to be preserved, got %+v", apiErr)
+	}
+	fields := apiErr.FieldErrors()
+	if got := fields["email"]; len(got) != 1 || got[0] != "Email is invalid" {
+		t.Errorf("Expected email field error, got %v", fields)
+	}
+	if got := fields["amount"]; len(got) != 1 || got[0] != "Amount is required" {
+		t.Errorf("Expected amount field error, got %v", fields)
+	}
+	if want := "paystack: Invalid params (HTTP 400): amount: Amount is required; email: Email is invalid"; err.Error() != want {
+		t.Errorf("Expected error %q, got %q", want, err.Error())
+	}
+}
+
+func TestMalformedSuccessBody(t *testing.T) {
+	for _, body := range []string{`{"status":true,"message":"Verification successful","data":{"refer`, `<html>OK</html>`} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		}))
+		client := NewClient("sk_test_key", nil)
+		client.baseURL, _ = url.Parse(srv.URL)
+		client.LoggingEnabled = false
+
+		_, err := client.Transaction.Verify("ref")
+		srv.Close()
+
+		var apiErr *APIError
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &apiErr) || !errors.As(err, &syntaxErr) || !strings.Contains(err.Error(), "decoding /transaction/verify/ref response") {
+			t.Errorf("%s: expected the decoding error, got %v", body, err)
+		}
+	}
+}
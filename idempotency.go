@@ -0,0 +1,140 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// IdempotencyStore maps logical operation keys, such as an order ID, to the
+// Paystack reference used for them. A store shared by several processes,
+// e.g. one backed by Redis SETNX, makes retries idempotent across them.
+type IdempotencyStore interface {
+	// Reserve returns the reference stored for key. If there is none, it
+	// stores reference for key and returns it. It must be atomic.
+	Reserve(ctx context.Context, key, reference string) (string, error)
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore for a single process.
+type MemoryIdempotencyStore struct {
+	// TTL is how long a key keeps its reference. Keys never expire when it
+	// is zero.
+	TTL time.Duration
+
+	mu        sync.Mutex
+	refs      map[string]storedReference
+	lastSweep time.Time
+}
+
+type storedReference struct {
+	reference string
+	expires   time.Time
+}
+
+// NewMemoryIdempotencyStore returns an empty store whose keys expire after ttl.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{TTL: ttl}
+}
+
+// Reserve returns the reference stored for key, storing reference if there is none.
+func (s *MemoryIdempotencyStore) Reserve(ctx context.Context, key, reference string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.refs == nil {
+		s.refs = make(map[string]storedReference)
+	}
+	if s.TTL > 0 && now.Sub(s.lastSweep) >= s.TTL {
+		s.lastSweep = now
+		for k, r := range s.refs {
+			if !now.Before(r.expires) {
+				delete(s.refs, k)
+			}
+		}
+	}
+
+	if r, ok := s.refs[key]; ok && (s.TTL <= 0 || now.Before(r.expires)) {
+		return r.reference, nil
+	}
+	s.refs[key] = storedReference{reference: reference, expires: now.Add(s.TTL)}
+	return reference, nil
+}
+
+// Idempotency makes mutations keyed by Paystack references safe to retry.
+// Each logical key is given one reference, which every attempt reuses, and
+// an attempt rejected as a duplicate reference returns the resource created
+// by an earlier one.
+type Idempotency struct {
+	Store      IdempotencyStore
+	References ReferenceGenerator
+}
+
+// NewIdempotency returns an Idempotency keeping references in store and
+// creating them with refs. A nil store is replaced with a
+// MemoryIdempotencyStore keeping keys for a day, and nil refs with
+// ULID references without a prefix.
+func NewIdempotency(store IdempotencyStore, refs ReferenceGenerator) *Idempotency {
+	if store == nil {
+		store = NewMemoryIdempotencyStore(24 * time.Hour)
+	}
+	if refs == nil {
+		refs, _ = ULIDReferences("")
+	}
+	return &Idempotency{Store: store, References: refs}
+}
+
+// Reference returns the reference for key, creating one on first use.
+func (i *Idempotency) Reference(ctx context.Context, key string) (string, error) {
+	if key == "" {
+		return "", errors.New("paystack: idempotency key is required")
+	}
+	ref, err := i.References()
+	if err != nil {
+		return "", err
+	}
+	return i.Store.Reserve(ctx, key, ref)
+}
+
+// Idempotent runs mutate with the reference for key. If Paystack rejects
+// the reference as a duplicate, because an earlier attempt succeeded,
+// fetch is called with it to return the existing resource instead.
+func Idempotent[T any](ctx context.Context, i *Idempotency, key string,
+	mutate func(ctx context.Context, ref string) (T, error),
+	fetch func(ctx context.Context, ref string) (T, error)) (T, error) {
+	ref, err := i.Reference(ctx, key)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	v, err := mutate(ctx, ref)
+	if errors.Is(err, ErrDuplicateReference) {
+		return fetch(ctx, ref)
+	}
+	return v, err
+}
+
+// ChargeAuthorization charges req idempotently under key. req.Reference is
+// ignored; the reference for key is used instead.
+func (i *Idempotency) ChargeAuthorization(ctx context.Context, c *Client, key string, req *TransactionRequest) (*Transaction, error) {
+	return Idempotent(ctx, i, key,
+		func(ctx context.Context, ref string) (*Transaction, error) {
+			r := *req
+			r.Reference = ref
+			return c.Transaction.ChargeAuthorizationContext(ctx, &r)
+		},
+		c.Transaction.VerifyContext)
+}
+
+// InitiateTransfer initiates req idempotently under key. req.Reference is
+// ignored; the reference for key is used instead.
+func (i *Idempotency) InitiateTransfer(ctx context.Context, c *Client, key string, req *TransferRequest) (*Transfer, error) {
+	return Idempotent(ctx, i, key,
+		func(ctx context.Context, ref string) (*Transfer, error) {
+			r := *req
+			r.Reference = ref
+			return c.Transfer.InitiateContext(ctx, &r)
+		},
+		c.Transfer.VerifyContext)
+}
@@ -0,0 +1,83 @@
+package paystack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdempotentTransfer(t *testing.T) {
+	var references []string
+	created := map[string]bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/transfer":
+			var req TransferRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			references = append(references, req.Reference)
+			if created[req.Reference] {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"status":false,"message":"Duplicate Transfer Reference","code":"duplicate_reference"}`)
+				return
+			}
+			created[req.Reference] = true
+			// the transfer is created, but the response is lost
+			w.WriteHeader(http.StatusGatewayTimeout)
+			fmt.Fprint(w, `{"status":false,"message":"Gateway timeout"}`)
+		case strings.HasPrefix(r.URL.Path, "/transfer/verify/"):
+			ref := strings.TrimPrefix(r.URL.Path, "/transfer/verify/")
+			fmt.Fprintf(w, `{"status":true,"message":"Transfer retrieved","data":{"id":7,"reference":%q,"status":"success"}}`, ref)
+		}
+	}))
+	defer srv.Close()
+
+	client, _ := NewClientWithOptions("sk_test_key", WithBaseURL(srv.URL))
+	refs, _ := ULIDReferences("payout")
+	idem := NewIdempotency(nil, refs)
+	req := &TransferRequest{Source: "balance", Amount: NewMoney(5000, "NGN"), Recipient: "RCP_1"}
+
+	ctx := context.Background()
+	if _, err := idem.InitiateTransfer(ctx, client, "payout-42", req); err == nil {
+		t.Fatal("Expected the first attempt to fail")
+	}
+	transfer, err := idem.InitiateTransfer(ctx, client, "payout-42", req)
+	if err != nil {
+		t.Fatalf("Expected the retry to return the existing transfer, got %v", err)
+	}
+
+	if len(references) != 2 || references[0] != references[1] || !strings.HasPrefix(references[0], "payout-") {
+		t.Errorf("Expected both attempts to use one reference, got %v", references)
+	}
+	if transfer.ID != 7 || transfer.Reference != references[0] {
+		t.Errorf("Expected the existing transfer, got %+v", transfer)
+	}
+	if req.Reference != "" {
+		t.Errorf("Expected the caller's request to be left unchanged")
+	}
+
+	other, _ := idem.Reference(ctx, "payout-43")
+	if other == references[0] {
+		t.Errorf("Expected a different key to get a different reference")
+	}
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	store := NewMemoryIdempotencyStore(20 * time.Millisecond)
+	ctx := context.Background()
+
+	if ref, _ := store.Reserve(ctx, "k", "ref-1"); ref != "ref-1" {
+		t.Errorf("Expected ref-1 to be stored, got %s", ref)
+	}
+	if ref, _ := store.Reserve(ctx, "k", "ref-2"); ref != "ref-1" {
+		t.Errorf("Expected ref-1 to be reused, got %s", ref)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if ref, _ := store.Reserve(ctx, "k", "ref-3"); ref != "ref-3" {
+		t.Errorf("Expected the key to have expired, got %s", ref)
+	}
+}
@@ -0,0 +1,145 @@
+package paystack
+
+import (
+	"context"
+	"iter"
+)
+
+// defaultIterPageSize is the page size used by iterators when none is given
+const defaultIterPageSize = 50
+
+// Iterator walks every record of a list endpoint, fetching pages lazily as
+// they are needed:
+//
+//	it := client.Customer.Iter(ctx, 100)
+//	for it.Next() {
+//		customer := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+//
+// An Iterator is not safe for concurrent use.
+type Iterator[T any] struct {
+	ctx     context.Context
+	fetch   func(ctx context.Context, perPage, page int) (*List[T], error)
+	perPage int
+
+	// fetchCursor, when set, pages by cursor instead of by page number
+	fetchCursor func(ctx context.Context, perPage int, cursor Cursor) (*List[T], error)
+
+	page    int // last page fetched
+	fetched bool
+	meta    ListMeta
+	buf     []T
+	cur     T
+	err     error
+	done    bool
+}
+
+func newIterator[T any](ctx context.Context, perPage int, fetch func(ctx context.Context, perPage, page int) (*List[T], error)) *Iterator[T] {
+	if perPage <= 0 {
+		perPage = defaultIterPageSize
+	}
+	return &Iterator[T]{ctx: ctx, fetch: fetch, perPage: perPage}
+}
+
+func newCursorIterator[T any](ctx context.Context, perPage int, fetch func(ctx context.Context, perPage int, cursor Cursor) (*List[T], error)) *Iterator[T] {
+	it := newIterator[T](ctx, perPage, nil)
+	it.fetchCursor = fetch
+	return it
+}
+
+// Next advances the iterator to the next record, fetching the next page if
+// needed. It returns false when the records are exhausted, on error, or
+// once the iterator's context is done.
+func (it *Iterator[T]) Next() bool {
+	if it.err == nil {
+		it.err = it.ctx.Err()
+	}
+	if it.err != nil {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if it.done || it.err != nil || !it.fetchNext() {
+			return false
+		}
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Value returns the current record.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the first error met while fetching pages.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Total returns the total number of records reported by Paystack,
+// fetching the first page if it has not been fetched yet.
+func (it *Iterator[T]) Total() int {
+	if !it.fetched && it.err == nil {
+		it.fetchNext()
+	}
+	return it.meta.Total
+}
+
+// Meta returns the pagination metadata of the last page fetched.
+func (it *Iterator[T]) Meta() ListMeta {
+	return it.meta
+}
+
+// All returns the remaining records as a sequence for use with range:
+//
+//	for customer, err := range client.Customer.Iter(ctx, 100).All() { ... }
+//
+// An error is yielded at most once, as the last element.
+func (it *Iterator[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if it.err != nil {
+			var zero T
+			yield(zero, it.err)
+		}
+	}
+}
+
+func (it *Iterator[T]) fetchNext() bool {
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	var list *List[T]
+	var err error
+	if it.fetchCursor != nil {
+		list, err = it.fetchCursor(it.ctx, it.perPage, Cursor{Next: it.meta.Next})
+	} else {
+		list, err = it.fetch(it.ctx, it.perPage, it.page+1)
+	}
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page++
+	it.fetched = true
+	it.meta = list.Meta
+	it.buf = append(it.buf, list.Values...)
+
+	switch {
+	case len(list.Values) == 0:
+		it.done = true
+	case it.fetchCursor != nil:
+		it.done = list.Meta.Next == ""
+	default:
+		// Not every endpoint reports a page count; a short page is the last one
+		it.done = len(list.Values) < it.perPage || (list.Meta.PageCount > 0 && it.page >= list.Meta.PageCount)
+	}
+	return true
+}
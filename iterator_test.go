@@ -0,0 +1,159 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// newPagingClient serves total customers, perPage at a time
+func newPagingClient(t *testing.T, total int, requests *[]int) *Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("perPage"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		*requests = append(*requests, page)
+
+		pageCount := (total + perPage - 1) / perPage
+		data := ""
+		for i := (page-1)*perPage + 1; i <= page*perPage && i <= total; i++ {
+			if data != "" {
+				data += ","
+			}
+			data += fmt.Sprintf(`{"id":%d}`, i)
+		}
+		fmt.Fprintf(w, `{"status":true,"message":"ok","data":[%s],"meta":{"total":%d,"perPage":%d,"page":%d,"pageCount":%d}}`,
+			data, total, perPage, page, pageCount)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient("sk_test_key", nil)
+	client.baseURL, _ = url.Parse(srv.URL)
+	client.LoggingEnabled = false
+	return client
+}
+
+func TestIterator(t *testing.T) {
+	var requests []int
+	client := newPagingClient(t, 7, &requests)
+
+	it := client.Customer.Iter(context.Background(), 3)
+	if it.Total() != 7 {
+		t.Errorf("Expected total of 7, got %d", it.Total())
+	}
+
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 7 || ids[0] != 1 || ids[6] != 7 {
+		t.Errorf("Expected customers 1 to 7, got %v", ids)
+	}
+	if fmt.Sprint(requests) != "[1 2 3]" {
+		t.Errorf("Expected pages 1 to 3 to be fetched once each, got %v", requests)
+	}
+}
+
+func TestIteratorAllIsLazy(t *testing.T) {
+	var requests []int
+	client := newPagingClient(t, 100, &requests)
+
+	n := 0
+	for cust, err := range client.Customer.Iter(context.Background(), 10).All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n++; n == 15 {
+			if cust.ID != 15 {
+				t.Errorf("Expected customer 15, got %d", cust.ID)
+			}
+			break
+		}
+	}
+	if len(requests) != 2 {
+		t.Errorf("Expected only 2 pages to be fetched, got %v", requests)
+	}
+}
+
+func TestIteratorContextCanceled(t *testing.T) {
+	var requests []int
+	client := newPagingClient(t, 100, &requests)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := client.Customer.Iter(ctx, 10)
+	for i := 0; i < 5 && it.Next(); i++ { // stop halfway through the first page
+	}
+	cancel()
+
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if n != 0 || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Expected iteration to stop with context.Canceled, got %d more records, %v", n, it.Err())
+	}
+}
+
+func TestIteratorCursor(t *testing.T) {
+	// records are listed newest first; the cursor is the last ID returned
+	ids := []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, q)
+		perPage, _ := strconv.Atoi(q.Get("perPage"))
+
+		start := 0
+		if next := q.Get("next"); next != "" {
+			after, _ := strconv.Atoi(next)
+			for start < len(ids) && ids[start] >= after {
+				start++
+			}
+		}
+		end := start + perPage
+		if end > len(ids) {
+			end = len(ids)
+		}
+		data, next := "", "null"
+		for _, id := range ids[start:end] {
+			if data != "" {
+				data += ","
+			}
+			data += fmt.Sprintf(`{"id":%d}`, id)
+		}
+		if end < len(ids) {
+			next = fmt.Sprintf(`"%d"`, ids[end-1])
+		}
+		fmt.Fprintf(w, `{"status":true,"message":"ok","data":[%s],"meta":{"perPage":%d,"next":%s,"previous":null}}`, data, perPage, next)
+
+		// a new transaction is created while we page
+		ids = append([]int{ids[0] + 1}, ids...)
+	}))
+	defer srv.Close()
+
+	client := NewClient("sk_test_key", nil)
+	client.baseURL, _ = url.Parse(srv.URL)
+	client.LoggingEnabled = false
+
+	var got []int
+	it := client.Transaction.IterCursor(context.Background(), 4)
+	for it.Next() {
+		got = append(got, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(got) != "[10 9 8 7 6 5 4 3 2 1]" {
+		t.Errorf("Expected each transaction exactly once, got %v", got)
+	}
+	if queries[0].Get("use_cursor") != "true" || queries[0].Get("next") != "" || queries[1].Get("next") != "7" {
+		t.Errorf("Expected cursor pagination queries, got %v", queries)
+	}
+}
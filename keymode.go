@@ -0,0 +1,60 @@
+package paystack
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors returned for API keys that may not be used.
+var (
+	ErrLiveKey   = errors.New("paystack: live API key refused")
+	ErrPublicKey = errors.New("paystack: endpoint requires a secret key, got a public key")
+)
+
+// KeyMode is the environment an API key belongs to.
+type KeyMode string
+
+// Key modes, parsed from the key prefix.
+const (
+	KeyModeUnknown KeyMode = ""
+	KeyModeTest    KeyMode = "test"
+	KeyModeLive    KeyMode = "live"
+)
+
+// ParseKey reports the mode of key and whether it is a public key,
+// from its sk_test_, sk_live_, pk_test_ or pk_live_ prefix.
+func ParseKey(key string) (mode KeyMode, public bool) {
+	kind, rest, ok := strings.Cut(key, "_")
+	if !ok || (kind != "sk" && kind != "pk") {
+		return KeyModeUnknown, false
+	}
+	switch {
+	case strings.HasPrefix(rest, "test_"):
+		mode = KeyModeTest
+	case strings.HasPrefix(rest, "live_"):
+		mode = KeyModeLive
+	default:
+		return KeyModeUnknown, false
+	}
+	return mode, kind == "pk"
+}
+
+// KeyMode returns the mode of the client's API key.
+func (c *Client) KeyMode() KeyMode {
+	mode, _ := ParseKey(c.key)
+	return mode
+}
+
+// IsLive reports whether the client uses a live key and so moves real money.
+func (c *Client) IsLive() bool {
+	return c.KeyMode() == KeyModeLive
+}
+
+// checkKey rejects calls that cannot succeed with the client's key.
+// Every endpoint the client implements requires a secret key.
+func (c *Client) checkKey() error {
+	if _, public := ParseKey(c.key); public {
+		return ErrPublicKey
+	}
+	return nil
+}
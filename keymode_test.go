@@ -0,0 +1,64 @@
+package paystack
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		key    string
+		mode   KeyMode
+		public bool
+	}{
+		{"sk_test_b748a89ad84f35c2f1a8b81681f956274de048bb", KeyModeTest, false},
+		{"sk_live_0000", KeyModeLive, false},
+		{"pk_test_0000", KeyModeTest, true},
+		{"pk_live_0000", KeyModeLive, true},
+		{"sk_prod_0000", KeyModeUnknown, false},
+		{"bearer-token", KeyModeUnknown, false},
+	}
+	for _, tt := range tests {
+		mode, public := ParseKey(tt.key)
+		if mode != tt.mode || public != tt.public {
+			t.Errorf("Expected %s to be %q (public %v), got %q (public %v)", tt.key, tt.mode, tt.public, mode, public)
+		}
+	}
+
+	if c := NewClient("sk_live_0000", nil); !c.IsLive() || c.KeyMode() != KeyModeLive {
+		t.Errorf("Expected client to report a live key")
+	}
+}
+
+func TestLiveKeyGuard(t *testing.T) {
+	if _, err := NewClientWithOptions("sk_live_0000", WithLiveKeyGuard(false)); !errors.Is(err, ErrLiveKey) {
+		t.Errorf("Expected ErrLiveKey, got %v", err)
+	}
+	if _, err := NewClientWithOptions("sk_live_0000", WithLiveKeyGuard(true)); err != nil {
+		t.Errorf("Expected an explicitly allowed live key to be accepted, got %v", err)
+	}
+	if _, err := NewClientWithOptions("sk_test_0000", WithLiveKeyGuard(false)); err != nil {
+		t.Errorf("Expected a test key to be accepted, got %v", err)
+	}
+}
+
+func TestPublicKeyRejected(t *testing.T) {
+	client, err := NewClientWithOptions("pk_test_0000", WithHTTPClient(&http.Client{Transport: failingTransport{t}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Transfer.Initiate(&TransferRequest{Amount: NewMoney(100, "NGN")}); !errors.Is(err, ErrPublicKey) {
+		t.Errorf("Expected ErrPublicKey, got %v", err)
+	}
+}
+
+func TestMustGetTestKeyRejectsLiveKey(t *testing.T) {
+	t.Setenv("PAYSTACK_KEY", "sk_live_0000")
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected mustGetTestKey to panic for a live key")
+		}
+	}()
+	mustGetTestKey()
+}
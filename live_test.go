@@ -0,0 +1,6 @@
+//go:build live
+
+package paystack
+
+// liveSuite runs the tests using c against Paystack: go test -tags live
+const liveSuite = true
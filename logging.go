@@ -0,0 +1,256 @@
+package paystack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Redaction masks the value of a sensitive field before it is logged.
+type Redaction func(value string) string
+
+var (
+	// RedactAll replaces the whole value.
+	RedactAll Redaction = func(string) string { return "[REDACTED]" }
+
+	// RedactPAN keeps only the last four digits of a card number.
+	RedactPAN Redaction = func(v string) string { return keepLast(v, 4) }
+
+	// RedactBearer keeps the key prefix of an Authorization header, such as
+	// "Bearer sk_test_", so that logs still show which mode was used.
+	RedactBearer Redaction = func(v string) string {
+		token := strings.TrimPrefix(v, "Bearer ")
+		for _, prefix := range []string{"sk_test_", "sk_live_", "pk_test_", "pk_live_"} {
+			if strings.HasPrefix(token, prefix) {
+				return "Bearer " + prefix + "[REDACTED]"
+			}
+		}
+		return "Bearer [REDACTED]"
+	}
+)
+
+// RedactionPolicy decides which request and response fields are masked
+// before anything is logged. Field names are matched case-insensitively
+// against JSON object keys and query parameters; headers are configured
+// separately.
+type RedactionPolicy struct {
+	fields  map[string]Redaction
+	headers map[string]Redaction
+}
+
+// NewRedactionPolicy returns a policy that masks nothing.
+func NewRedactionPolicy() *RedactionPolicy {
+	return &RedactionPolicy{
+		fields:  map[string]Redaction{},
+		headers: map[string]Redaction{},
+	}
+}
+
+// DefaultRedactionPolicy returns the built-in policy. It masks card numbers,
+// CVVs, PINs, OTPs, BVNs, authorization codes and the API key.
+func DefaultRedactionPolicy() *RedactionPolicy {
+	return NewRedactionPolicy().
+		Field("card_number", RedactPAN).
+		Field("pan", RedactPAN).
+		Field("card_cvc", RedactAll).
+		Field("cvc", RedactAll).
+		Field("cvv", RedactAll).
+		Field("pin", RedactAll).
+		Field("otp", RedactAll).
+		Field("bvn", RedactAll).
+		Field("authorization_code", RedactAll).
+		Field("authorization", RedactAll).
+		Header("Authorization", RedactBearer)
+}
+
+// Field sets how a JSON field or query parameter is masked. A nil r stops
+// masking it. It returns p so that calls can be chained.
+func (p *RedactionPolicy) Field(name string, r Redaction) *RedactionPolicy {
+	setRedaction(p.fields, name, r)
+	return p
+}
+
+// Header sets how an HTTP header is masked. A nil r stops masking it.
+// It returns p so that calls can be chained.
+func (p *RedactionPolicy) Header(name string, r Redaction) *RedactionPolicy {
+	setRedaction(p.headers, name, r)
+	return p
+}
+
+func setRedaction(rules map[string]Redaction, name string, r Redaction) {
+	if r == nil {
+		delete(rules, strings.ToLower(name))
+	} else {
+		rules[strings.ToLower(name)] = r
+	}
+}
+
+func (p *RedactionPolicy) rule(field string) Redaction {
+	return p.fields[strings.ToLower(field)]
+}
+
+// RedactJSON returns a copy of the JSON document b with sensitive fields
+// masked. Bodies that are not valid JSON are omitted entirely.
+func (p *RedactionPolicy) RedactJSON(b []byte) []byte {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return []byte(`"[unparseable body omitted]"`)
+	}
+	out, _ := json.Marshal(p.redactValue("", doc))
+	return out
+}
+
+func (p *RedactionPolicy) redactValue(field string, v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = p.redactValue(k, val)
+		}
+		return t
+	case []interface{}:
+		for i, val := range t {
+			t[i] = p.redactValue(field, val)
+		}
+		return t
+	case nil:
+		return nil
+	}
+	if r := p.rule(field); r != nil {
+		switch t := v.(type) {
+		case string:
+			return r(t)
+		case json.Number:
+			return r(t.String())
+		}
+	}
+	return v
+}
+
+// RedactHeader returns a copy of h with sensitive headers masked.
+func (p *RedactionPolicy) RedactHeader(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for k, vals := range h {
+		r := p.headers[strings.ToLower(k)]
+		for _, v := range vals {
+			if r != nil {
+				v = r(v)
+			}
+			out.Add(k, v)
+		}
+	}
+	return out
+}
+
+// sensitivePathParams maps endpoints that carry a sensitive value as their
+// last path segment to the field that value represents
+var sensitivePathParams = map[string]string{
+	"/bank/resolve_bvn/": "bvn",
+	"/bvn/match/":        "bvn",
+}
+
+// RedactURL returns the path and query of u with sensitive values masked.
+func (p *RedactionPolicy) RedactURL(u *url.URL) string {
+	path := u.Path
+	for prefix, field := range sensitivePathParams {
+		if i := strings.Index(path, prefix); i >= 0 {
+			if r := p.rule(field); r != nil {
+				seg := path[i+len(prefix):]
+				path = path[:i+len(prefix)] + r(seg)
+			}
+		}
+	}
+	if u.RawQuery == "" {
+		return path
+	}
+	q := u.Query()
+	for k, vals := range q {
+		if r := p.rule(k); r != nil {
+			for i, v := range vals {
+				vals[i] = r(v)
+			}
+		}
+	}
+	return path + "?" + q.Encode()
+}
+
+func keepLast(v string, n int) string {
+	if len(v) <= n {
+		return strings.Repeat("*", len(v))
+	}
+	return strings.Repeat("*", len(v)-n) + v[len(v)-n:]
+}
+
+// redaction returns the policy used to mask logged data
+func (c *Client) redaction() *RedactionPolicy {
+	if c.Redaction != nil {
+		return c.Redaction
+	}
+	return defaultRedaction
+}
+
+var defaultRedaction = DefaultRedactionPolicy()
+
+// logAttempt writes a structured record of a single HTTP attempt to c.Logger
+func (c *Client) logAttempt(ctx context.Context, inv *Invocation, req *http.Request, payload []byte, resp *http.Response, latency time.Duration, attempt int, err error) {
+	if c.Logger == nil {
+		return
+	}
+	policy := c.redaction()
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", policy.RedactURL(req.URL)),
+		slog.Duration("latency", latency),
+		slog.Int("attempt", attempt),
+	}
+	if op := inv.Operation.String(); op != "" {
+		attrs = append(attrs, slog.String("operation", op))
+	}
+
+	level := slog.LevelInfo
+	switch {
+	case err != nil:
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", err.Error()))
+	case resp.StatusCode >= 500:
+		level = slog.LevelError
+	case resp.StatusCode >= 400:
+		level = slog.LevelWarn
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+
+	if c.Logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("request_header", policy.RedactHeader(req.Header)))
+		if payload != nil {
+			attrs = append(attrs, slog.String("request_body", string(policy.RedactJSON(payload))))
+		}
+	}
+	c.Logger.LogAttrs(ctx, level, "paystack request", attrs...)
+}
+
+// logResponseBody writes the redacted response body to c.Logger at debug level
+func (c *Client) logResponseBody(httpResp *http.Response, body []byte) {
+	if c.Logger == nil {
+		return
+	}
+	ctx := httpResp.Request.Context()
+	if !c.Logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	c.Logger.LogAttrs(ctx, slog.LevelDebug, "paystack response",
+		slog.Int("status", httpResp.StatusCode),
+		slog.String("response_body", string(c.redaction().RedactJSON(body))),
+	)
+}
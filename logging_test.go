@@ -0,0 +1,91 @@
+package paystack
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	body := []byte(`{"email":"a@b.com","pin":"1234","card":{"card_number":"5078507850785078","card_cvc":"081"},"amount":5000,"authorization":{"authorization_code":"AUTH_xyz","last4":"5078"}}`)
+	var got map[string]interface{}
+	if err := json.Unmarshal(DefaultRedactionPolicy().RedactJSON(body), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	card := got["card"].(map[string]interface{})
+	if card["card_number"] != "************5078" {
+		t.Errorf("Expected PAN to keep last 4 digits, got %v", card["card_number"])
+	}
+	if card["card_cvc"] != "[REDACTED]" || got["pin"] != "[REDACTED]" {
+		t.Errorf("Expected CVV and PIN to be redacted, got %v", got)
+	}
+	if got["authorization"].(map[string]interface{})["authorization_code"] != "[REDACTED]" {
+		t.Errorf("Expected authorization code to be redacted, got %v", got["authorization"])
+	}
+	if got["email"] != "a@b.com" || got["amount"] != float64(5000) {
+		t.Errorf("Expected other fields to be left intact, got %v", got)
+	}
+
+	policy := DefaultRedactionPolicy().Field("pin", nil).Field("email", RedactAll)
+	if out := string(policy.RedactJSON(body)); !strings.Contains(out, `"pin":"1234"`) || strings.Contains(out, "a@b.com") {
+		t.Errorf("Expected per-field policy to apply, got %s", out)
+	}
+}
+
+func TestRedactURLAndHeader(t *testing.T) {
+	policy := DefaultRedactionPolicy()
+	u, _ := url.Parse("https://api.paystack.co/bank/resolve_bvn/22222222222")
+	if got := policy.RedactURL(u); got != "/bank/resolve_bvn/[REDACTED]" {
+		t.Errorf("Expected BVN to be redacted from path, got %v", got)
+	}
+
+	h := http.Header{}
+	h.Set("Authorization", "Bearer sk_live_abcdef")
+	if got := policy.RedactHeader(h).Get("Authorization"); got != "Bearer sk_live_[REDACTED]" {
+		t.Errorf("Expected bearer key to be redacted, got %v", got)
+	}
+}
+
+func TestSlogLogging(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":true,"message":"Charge attempted","data":{"status":"success","reference":"ref"}}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	client, err := NewClientWithOptions("sk_test_secretkey",
+		WithBaseURL(srv.URL),
+		WithSlogHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Charge.Create(&ChargeRequest{
+		Email:  "a@b.com",
+		Amount: NewMoney(5000, "NGN"),
+		Card:   &Card{Number: "5078507850785078", CVV: "081"},
+		Pin:    "1234",
+	})
+	if err != nil {
+		t.Fatalf("Charge returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"5078507850785078", `\"081\"`, `\"1234\"`, "secretkey"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Expected %s to be redacted from logs:\n%s", secret, out)
+		}
+	}
+	for _, field := range []string{`"method":"POST"`, `"path":"/charge"`, `"status":200`, `"latency":`, `"operation":"Charge.Create"`} {
+		if !strings.Contains(out, field) {
+			t.Errorf("Expected log to contain %s:\n%s", field, out)
+		}
+	}
+}
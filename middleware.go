@@ -0,0 +1,90 @@
+package paystack
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Operation names the service method behind an API call,
+// e.g. Service "Transfer" and Method "Initiate".
+type Operation struct {
+	Service string
+	Method  string
+}
+
+// String returns the operation as "Service.Method"
+func (op Operation) String() string {
+	if op.Service == "" {
+		return op.Method
+	}
+	return op.Service + "." + op.Method
+}
+
+// Invocation is a single API call as it passes through the interceptor chain.
+type Invocation struct {
+	// Operation is the service method making the call. It is empty for
+	// calls made directly through Client.Call.
+	Operation Operation
+
+	// Request is the outgoing HTTP request. Interceptors may modify it,
+	// e.g. to add tracing headers, before calling the next Handler.
+	Request *http.Request
+
+	// Result receives the decoded response once the next Handler returns
+	// without error. On failure the Handler returns an *APIError instead.
+	Result interface{}
+
+	// Response describes the HTTP response once the next Handler returns.
+	// It is nil if no response was received.
+	Response *ResponseInfo
+}
+
+// Handler performs an API call.
+type Handler func(inv *Invocation) error
+
+// Interceptor wraps a Handler with additional behaviour such as audit
+// logging, metrics or a test double that never reaches the network.
+type Interceptor func(next Handler) Handler
+
+// Use appends interceptors to the chain wrapped around every API call made
+// by the client. The first interceptor added is the outermost one.
+func (c *Client) Use(interceptors ...Interceptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
+// handler builds the interceptor chain around c.send
+func (c *Client) handler() Handler {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	h := Handler(c.send)
+	if c.Cache != nil {
+		h = c.cached(h)
+	}
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		h = c.interceptors[i](h)
+	}
+	return h
+}
+
+type operationKey struct{}
+
+func operationFromContext(ctx context.Context) Operation {
+	op, _ := ctx.Value(operationKey{}).(Operation)
+	return op
+}
+
+// call is CallContext for service methods, tagging the call with op,
+// given as "Service.Method"
+func (c *Client) call(ctx context.Context, op, method, path string, body, v interface{}) error {
+	var operation Operation
+	if i := strings.IndexByte(op, '.'); i >= 0 {
+		operation = Operation{Service: op[:i], Method: op[i+1:]}
+	} else {
+		operation = Operation{Method: op}
+	}
+	return c.CallContext(context.WithValue(ctx, operationKey{}, operation), method, path, body, v)
+}
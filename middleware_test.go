@@ -0,0 +1,100 @@
+package paystack
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestInterceptorChain(t *testing.T) {
+	var gotHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Correlation-ID")
+		if r.URL.Path == "/transfer/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":false,"message":"Transfer not found"}`))
+			return
+		}
+		w.Write([]byte(`{"status":true,"message":"ok","data":{"transfer_code":"TRF_1"}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("sk_test_key", nil)
+	client.baseURL, _ = url.Parse(srv.URL)
+	client.LoggingEnabled = false
+
+	var order []string
+	var ops []string
+	var results []interface{}
+	var errs []error
+	client.Use(
+		func(next Handler) Handler {
+			return func(inv *Invocation) error {
+				order = append(order, "outer")
+				inv.Request.Header.Set("X-Correlation-ID", "abc-123")
+				return next(inv)
+			}
+		},
+		func(next Handler) Handler {
+			return func(inv *Invocation) error {
+				order = append(order, "inner")
+				err := next(inv)
+				ops = append(ops, inv.Operation.String())
+				results = append(results, inv.Result)
+				errs = append(errs, err)
+				return err
+			}
+		},
+	)
+
+	transfer, err := client.Transfer.Get("TRF_1")
+	if err != nil {
+		t.Fatalf("GET Transfer returned error: %v", err)
+	}
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("Expected interceptors to run outer first, got %v", order)
+	}
+	if gotHeader != "abc-123" {
+		t.Errorf("Expected correlation header to reach the server, got %q", gotHeader)
+	}
+	if ops[0] != "Transfer.Get" {
+		t.Errorf("Expected operation Transfer.Get, got %q", ops[0])
+	}
+	if results[0] != transfer || transfer.TransferCode != "TRF_1" {
+		t.Errorf("Expected interceptor to see decoded transfer, got %+v", results[0])
+	}
+
+	_, err = client.Transfer.Get("missing")
+	var apiErr *APIError
+	if !errors.As(errs[1], &apiErr) || apiErr.HTTPStatusCode != http.StatusNotFound {
+		t.Errorf("Expected interceptor to see *APIError with 404, got %v", errs[1])
+	}
+	if err != errs[1] {
+		t.Errorf("Expected caller to get the interceptor's error")
+	}
+}
+
+func TestInterceptorTestDouble(t *testing.T) {
+	client := NewClient("sk_test_key", &http.Client{Transport: failingTransport{t}})
+	client.LoggingEnabled = false
+	client.Use(func(next Handler) Handler {
+		return func(inv *Invocation) error {
+			return json.Unmarshal([]byte(`{"email":"stub@example.com"}`), inv.Result)
+		}
+	})
+
+	cust, err := client.Customer.Get("CUS_1")
+	if err != nil || cust.Email != "stub@example.com" {
+		t.Errorf("Expected stubbed customer, got %+v, %v", cust, err)
+	}
+}
+
+type failingTransport struct{ t *testing.T }
+
+func (f failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	f.t.Errorf("Unexpected request to %v", r.URL)
+	return nil, errors.New("unexpected request")
+}
@@ -0,0 +1,383 @@
+package paystack
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Errors returned by Money arithmetic and parsing.
+var (
+	ErrCurrencyMismatch = errors.New("paystack: currency mismatch")
+	ErrMoneyOverflow    = errors.New("paystack: money overflow")
+)
+
+// currency describes how amounts in an ISO 4217 currency are written.
+type currency struct {
+	symbol   string
+	exponent int // number of minor-unit digits
+}
+
+// currencies Paystack settles in. Other codes are formatted with
+// two minor-unit digits and the code in place of a symbol.
+var currencies = map[string]currency{
+	"NGN": {"₦", 2},
+	"GHS": {"GH₵", 2},
+	"KES": {"KSh", 2},
+	"ZAR": {"R", 2},
+	"USD": {"$", 2},
+}
+
+func lookupCurrency(code string) currency {
+	if c, ok := currencies[code]; ok {
+		return c
+	}
+	if code == "" {
+		return currency{exponent: 2}
+	}
+	return currency{symbol: code + " ", exponent: 2}
+}
+
+// Money is an amount in the minor unit of its currency: kobo for NGN,
+// pesewas for GHS, cents for KES, ZAR and USD.
+//
+// Money is sent to and received from Paystack as an integer count of minor
+// units. The currency travels in the currency field of the enclosing object;
+// decoded responses copy it into each Money value they contain.
+type Money struct {
+	Amount   int64  // minor units
+	Currency string // ISO 4217 code, e.g. NGN
+}
+
+// NewMoney returns amount minor units of currency.
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// ParseMoney parses a major-unit amount such as "1234.56", "1,234.56" or
+// "₦1,234.56" in currency. It fails if s has more decimal places than the
+// currency has minor units, or if s names a different currency.
+func ParseMoney(s, currency string) (Money, error) {
+	currency = strings.ToUpper(currency)
+	cur := lookupCurrency(currency)
+	str := strings.TrimSpace(s)
+
+	neg := strings.HasPrefix(str, "-")
+	if neg {
+		str = strings.TrimSpace(str[1:])
+	}
+	if strings.HasPrefix(str, currency) && currency != "" {
+		str = str[len(currency):]
+	} else if sym := strings.TrimSpace(cur.symbol); sym != "" && strings.HasPrefix(str, sym) {
+		str = str[len(sym):]
+	}
+	str = strings.ReplaceAll(strings.TrimSpace(str), ",", "")
+
+	whole, frac, hasFrac := strings.Cut(str, ".")
+	if whole == "" && frac == "" || hasFrac && frac == "" {
+		return Money{}, fmt.Errorf("paystack: invalid amount %q", s)
+	}
+	if len(frac) > cur.exponent {
+		return Money{}, fmt.Errorf("paystack: amount %q has more than %d decimal places", s, cur.exponent)
+	}
+	digits := whole + frac + strings.Repeat("0", cur.exponent-len(frac))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return Money{}, fmt.Errorf("paystack: invalid amount %q", s)
+		}
+	}
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, ErrMoneyOverflow
+	}
+	if neg {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// IsZero reports whether m is a zero amount.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative reports whether m is less than zero.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// currencyWith returns the currency of m and n, which must be the same.
+// A Money without a currency takes on the currency of the other operand.
+func (m Money) currencyWith(n Money) (string, error) {
+	switch {
+	case m.Currency == n.Currency || n.Currency == "":
+		return m.Currency, nil
+	case m.Currency == "":
+		return n.Currency, nil
+	}
+	return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, n.Currency)
+}
+
+// Add returns m+n. It fails if the currencies differ or the sum overflows.
+func (m Money) Add(n Money) (Money, error) {
+	cur, err := m.currencyWith(n)
+	if err != nil {
+		return Money{}, err
+	}
+	sum := m.Amount + n.Amount
+	if (n.Amount > 0 && sum < m.Amount) || (n.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: sum, Currency: cur}, nil
+}
+
+// Sub returns m-n. It fails if the currencies differ or the difference overflows.
+func (m Money) Sub(n Money) (Money, error) {
+	if n.Amount == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	return m.Add(Money{Amount: -n.Amount, Currency: n.Currency})
+}
+
+// Mul returns m*n. It fails if the product overflows.
+func (m Money) Mul(n int64) (Money, error) {
+	p := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(n))
+	if !p.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{Amount: p.Int64(), Currency: m.Currency}, nil
+}
+
+// Cmp compares m and n, returning -1, 0 or +1. It fails if the currencies differ.
+func (m Money) Cmp(n Money) (int, error) {
+	if _, err := m.currencyWith(n); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < n.Amount:
+		return -1, nil
+	case m.Amount > n.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// String formats m in major units with its currency symbol, e.g. ₦1,234.56.
+func (m Money) String() string {
+	cur := lookupCurrency(m.Currency)
+
+	// work on the unsigned magnitude so that math.MinInt64 formats correctly
+	abs := uint64(m.Amount)
+	sign := ""
+	if m.Amount < 0 {
+		abs = -abs
+		sign = "-"
+	}
+	digits := strconv.FormatUint(abs, 10)
+	if len(digits) <= cur.exponent {
+		digits = strings.Repeat("0", cur.exponent-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-cur.exponent], digits[len(digits)-cur.exponent:]
+
+	var b strings.Builder
+	b.WriteString(sign)
+	b.WriteString(cur.symbol)
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		b.WriteByte('.')
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// MarshalJSON encodes m as an integer count of minor units.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, m.Amount, 10), nil
+}
+
+// UnmarshalJSON decodes a count of minor units. Numbers written as strings
+// or with a zero fraction, as some endpoints return them, are accepted;
+// null leaves m unchanged.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if bytes.Equal(data, []byte(`""`)) {
+		m.Amount = 0
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("paystack: cannot decode %s as an amount", data)
+	}
+	if i, err := n.Int64(); err == nil {
+		m.Amount = i
+		return nil
+	}
+	f, err := n.Float64()
+	if err != nil || f != math.Trunc(f) || math.Abs(f) >= 1<<63 {
+		return fmt.Errorf("paystack: cannot decode %s as an amount", data)
+	}
+	m.Amount = int64(f)
+	return nil
+}
+
+var moneyType = reflect.TypeOf(Money{})
+
+// FillCurrencies sets the currency of the Money values reachable from v,
+// a pointer, that have none, from the currency field beside them. The
+// client does this for every response; use it for payloads decoded
+// elsewhere, such as webhook events.
+func FillCurrencies(v interface{}) {
+	setCurrencies(v)
+}
+
+// setCurrencies copies the currency field of each struct reachable from v
+// into the Money fields beside it. Structs without a currency field inherit
+// the currency of the struct that contains them.
+func setCurrencies(v interface{}) {
+	setCurrency(reflect.ValueOf(v), "", 0)
+}
+
+func setCurrency(rv reflect.Value, cur string, depth int) {
+	// response types refer to one another; bound the walk rather than
+	// tracking visited pointers
+	if depth > 8 {
+		return
+	}
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if !rv.IsNil() {
+			setCurrency(rv.Elem(), cur, depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			setCurrency(rv.Index(i), cur, depth+1)
+		}
+	case reflect.Struct:
+		if rv.Type() == moneyType {
+			if f := rv.Field(1); f.CanSet() && f.String() == "" {
+				f.SetString(cur)
+			}
+			return
+		}
+		if f := rv.FieldByName("Currency"); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			cur = f.String()
+		}
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).IsExported() {
+				setCurrency(rv.Field(i), cur, depth+1)
+			}
+		}
+	}
+}
+
+// requestCurrencies returns body with the currency of each Money value
+// copied into the empty currency field beside it, since Money is sent as a
+// bare amount. body itself is left alone: the structs and slices that change
+// are copied. It fails with ErrCurrencyMismatch when a currency field and a
+// Money value beside it disagree.
+func requestCurrencies(body interface{}) (interface{}, error) {
+	rv, changed, err := requestCurrency(reflect.ValueOf(body), 0)
+	if err != nil || !changed {
+		return body, err
+	}
+	return rv.Interface(), nil
+}
+
+func requestCurrency(rv reflect.Value, depth int) (reflect.Value, bool, error) {
+	if depth > 8 {
+		return rv, false, nil
+	}
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return rv, false, nil
+		}
+		elem, changed, err := requestCurrency(rv.Elem(), depth+1)
+		if err != nil || !changed {
+			return rv, false, err
+		}
+		p := reflect.New(elem.Type())
+		p.Elem().Set(elem)
+		return p, true, nil
+	case reflect.Slice:
+		var out reflect.Value
+		for i := 0; i < rv.Len(); i++ {
+			v, changed, err := requestCurrency(rv.Index(i), depth+1)
+			if err != nil {
+				return rv, false, fmt.Errorf("item %d: %w", i, err)
+			}
+			if !changed {
+				continue
+			}
+			if !out.IsValid() {
+				out = reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+				reflect.Copy(out, rv)
+			}
+			out.Index(i).Set(v)
+		}
+		if !out.IsValid() {
+			return rv, false, nil
+		}
+		return out, true, nil
+	case reflect.Struct:
+		if rv.Type() == moneyType {
+			return rv, false, nil
+		}
+		out := rv
+		changed := false
+		// own copies rv before its first change
+		own := func() {
+			if !changed {
+				out = reflect.New(rv.Type()).Elem()
+				out.Set(rv)
+				changed = true
+			}
+		}
+		for i := 0; i < rv.NumField(); i++ {
+			if !rv.Type().Field(i).IsExported() {
+				continue
+			}
+			f := rv.Field(i)
+			if f.Type() == moneyType {
+				money := f.Field(1).String()
+				c := out.FieldByName("Currency")
+				if money == "" || !c.IsValid() || c.Kind() != reflect.String {
+					continue
+				}
+				switch c.String() {
+				case money:
+				case "":
+					own()
+					out.FieldByName("Currency").SetString(money)
+				default:
+					return rv, false, fmt.Errorf("%w: %s amount, %s currency", ErrCurrencyMismatch, money, c.String())
+				}
+				continue
+			}
+			v, fchanged, err := requestCurrency(f, depth+1)
+			if err != nil {
+				return rv, false, err
+			}
+			if fchanged {
+				own()
+				out.Field(i).Set(v)
+			}
+		}
+		return out, changed, nil
+	}
+	return rv, false, nil
+}
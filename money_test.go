@@ -0,0 +1,175 @@
+package paystack
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{NewMoney(123456, "NGN"), "₦1,234.56"},
+		{NewMoney(5, "ghs"), "GH₵0.05"},
+		{NewMoney(100000000, "KES"), "KSh1,000,000.00"},
+		{NewMoney(-9950, "ZAR"), "-R99.50"},
+		{NewMoney(1, "USD"), "$0.01"},
+		{NewMoney(250, "XOF"), "XOF 2.50"},
+		{Money{Amount: 0}, "0.00"},
+		{NewMoney(math.MinInt64, "USD"), "-$92,233,720,368,547,758.08"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("Expected %+v to format as %q, got %q", tt.m, tt.want, got)
+		}
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		s, currency string
+		want        int64
+	}{
+		{"1234.56", "NGN", 123456},
+		{"₦1,234.56", "NGN", 123456},
+		{"GH₵ 10", "GHS", 1000},
+		{"KES 0.5", "KES", 50},
+		{"-R99.50", "ZAR", -9950},
+		{".75", "USD", 75},
+	}
+	for _, tt := range tests {
+		m, err := ParseMoney(tt.s, tt.currency)
+		if err != nil {
+			t.Errorf("ParseMoney(%q): %v", tt.s, err)
+			continue
+		}
+		if m.Amount != tt.want || m.Currency != tt.currency {
+			t.Errorf("Expected ParseMoney(%q) to be %d %s, got %+v", tt.s, tt.want, tt.currency, m)
+		}
+	}
+
+	for _, s := range []string{"", "1.234", "$12", "12.", "1e5", "99999999999999999999"} {
+		if _, err := ParseMoney(s, "NGN"); err == nil {
+			t.Errorf("Expected ParseMoney(%q) to fail", s)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a, b := NewMoney(1050, "NGN"), NewMoney(75, "NGN")
+	if sum, err := a.Add(b); err != nil || sum != NewMoney(1125, "NGN") {
+		t.Errorf("Expected ₦11.25, got %v, %v", sum, err)
+	}
+	if diff, err := b.Sub(a); err != nil || diff != NewMoney(-975, "NGN") {
+		t.Errorf("Expected -₦9.75, got %v, %v", diff, err)
+	}
+	if p, err := a.Mul(3); err != nil || p != NewMoney(3150, "NGN") {
+		t.Errorf("Expected ₦31.50, got %v, %v", p, err)
+	}
+	if c, err := a.Cmp(b); err != nil || c != 1 {
+		t.Errorf("Expected ₦10.50 > ₦0.75, got %d, %v", c, err)
+	}
+	if sum, err := (Money{Amount: 5}).Add(b); err != nil || sum.Currency != "NGN" {
+		t.Errorf("Expected a Money without currency to take on NGN, got %+v, %v", sum, err)
+	}
+
+	if _, err := a.Add(NewMoney(1, "GHS")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+	if _, err := NewMoney(math.MaxInt64, "NGN").Add(NewMoney(1, "NGN")); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Expected ErrMoneyOverflow from Add, got %v", err)
+	}
+	if _, err := NewMoney(0, "NGN").Sub(NewMoney(math.MinInt64, "NGN")); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Expected ErrMoneyOverflow from Sub, got %v", err)
+	}
+	if _, err := NewMoney(math.MaxInt64/2+1, "NGN").Mul(2); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Expected ErrMoneyOverflow from Mul, got %v", err)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	b, err := json.Marshal(&RefundRequest{Transaction: "ref1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"transaction":"ref1"}` {
+		t.Errorf("Expected a zero amount to be left out, got %s", b)
+	}
+	b, _ = json.Marshal(&TransferRequest{Amount: NewMoney(9007199254740993, "NGN")})
+	if string(b) != `{"amount":9007199254740993}` {
+		t.Errorf("Expected amount to be encoded exactly in minor units, got %s", b)
+	}
+
+	for body, want := range map[string]int64{`5000`: 5000, `"7000"`: 7000, `250.0`: 250, `null`: 0, `""`: 0} {
+		var p Product
+		if err := json.Unmarshal([]byte(`{"price":`+body+`}`), &p); err != nil || p.Price.Amount != want {
+			t.Errorf("Expected price %s to decode to %d, got %+v, %v", body, want, p.Price, err)
+		}
+	}
+	var p Product
+	if err := json.Unmarshal([]byte(`{"price":12.5}`), &p); err == nil {
+		t.Errorf("Expected a fractional minor-unit amount to fail")
+	}
+}
+
+func TestRequestSendsCurrency(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		io.WriteString(w, `{"status":true,"message":"ok","data":{}}`)
+	}))
+	defer srv.Close()
+	client, err := NewClientWithOptions("sk_test_key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.LoggingEnabled = false
+
+	req := &TransferRequest{Source: "balance", Amount: NewMoney(10000, "GHS"), Recipient: "RCP_1"}
+	if _, err := client.Transfer.Initiate(req); err != nil {
+		t.Fatal(err)
+	}
+	if body != `{"source":"balance","amount":10000,"currency":"GHS","recipient":"RCP_1"}`+"\n" {
+		t.Errorf("Expected the amount's currency to be sent, got %s", body)
+	}
+	if req.Currency != "" {
+		t.Errorf("Expected the request to be left alone, got currency %q", req.Currency)
+	}
+
+	items := []BulkItem{{Authorization: "AUTH_1", Amount: NewMoney(500, "GHS")}}
+	if _, err := client.BulkCharge.Initiate(&BulkChargeRequest{Items: items}); err != nil {
+		t.Fatal(err)
+	}
+	if body != `[{"authorization":"AUTH_1","amount":500,"currency":"GHS"}]`+"\n" {
+		t.Errorf("Expected each item's currency to be sent, got %s", body)
+	}
+
+	body = ""
+	req = &TransferRequest{Amount: NewMoney(10000, "GHS"), Currency: "NGN", Recipient: "RCP_1"}
+	if _, err := client.Transfer.Initiate(req); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+	if body != "" {
+		t.Errorf("Expected a mismatched request not to be sent, got %s", body)
+	}
+}
+
+func TestDecodeSetsCurrency(t *testing.T) {
+	c := NewClient("sk_test_key", nil)
+	c.LoggingEnabled = false
+
+	txn := &Transaction{}
+	if err := c.decodeResponse(newTestResponse(200, transactionBody), txn); err != nil {
+		t.Fatal(err)
+	}
+	if txn.Amount.String() != "₦50.00" || txn.Fees.String() != "₦0.75" {
+		t.Errorf("Expected amounts in NGN, got %v and %v", txn.Amount, txn.Fees)
+	}
+}
@@ -0,0 +1,283 @@
+package paystack
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures a Client built with NewClientWithOptions.
+type Option func(*clientOptions) error
+
+type clientOptions struct {
+	baseURL         *url.URL
+	httpClient      *http.Client
+	timeout         time.Duration
+	logger          Logger
+	slogLogger      *slog.Logger
+	redaction       *RedactionPolicy
+	userAgentSuffix []string
+	retry           *RetryPolicy
+	rateLimiter     *RateLimiter
+	tracer          Tracer
+	metrics         Metrics
+	circuitBreaker  *CircuitBreaker
+	cache           Cache
+	cacheTTLs       map[string]time.Duration
+	liveKeyGuard    bool
+	allowLiveKey    bool
+}
+
+// NewClientWithOptions creates a new Paystack API client with the given
+// secret key, configured by opts. Unlike NewClient, logging is off unless
+// a logger is given with WithLogger or WithSlogHandler.
+//
+// The returned Client is safe for concurrent use by multiple goroutines.
+// Its exported fields must not be modified once it is in use.
+func NewClientWithOptions(key string, opts ...Option) (*Client, error) {
+	if strings.TrimSpace(key) == "" {
+		return nil, errors.New("paystack: API key is required")
+	}
+
+	o := &clientOptions{}
+	o.baseURL, _ = url.Parse(baseURL)
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	if mode, _ := ParseKey(key); o.liveKeyGuard && !o.allowLiveKey && mode == KeyModeLive {
+		return nil, ErrLiveKey
+	}
+
+	httpClient := &http.Client{Timeout: defaultHTTPTimeout}
+	if o.httpClient != nil {
+		// copy the caller's client so that WithTimeout does not leak into it
+		hc := *o.httpClient
+		httpClient = &hc
+	}
+	if o.timeout > 0 {
+		httpClient.Timeout = o.timeout
+	}
+
+	ua := userAgent
+	if len(o.userAgentSuffix) > 0 {
+		ua += " " + strings.Join(o.userAgentSuffix, " ")
+	}
+
+	c := &Client{
+		client:         httpClient,
+		key:            key,
+		baseURL:        o.baseURL,
+		userAgent:      ua,
+		LoggingEnabled: o.logger != nil,
+		Log:            o.logger,
+		Logger:         o.slogLogger,
+		Redaction:      o.redaction,
+		Retry:          o.retry,
+		RateLimiter:    o.rateLimiter,
+		Tracer:         o.tracer,
+		Metrics:        o.metrics,
+		CircuitBreaker: o.circuitBreaker,
+		Cache:          o.cache,
+		CacheTTLs:      o.cacheTTLs,
+	}
+	c.initServices()
+
+	return c, nil
+}
+
+// WithBaseURL sets the base URL of the Paystack API, for example to point
+// the client at a local stub server.
+func WithBaseURL(rawURL string) Option {
+	return func(o *clientOptions) error {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("paystack: invalid base URL: %v", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("paystack: base URL must be an absolute http(s) URL, got %q", rawURL)
+		}
+		if !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+		o.baseURL = u
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to communicate with the API.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) error {
+		if httpClient == nil {
+			return errors.New("paystack: HTTP client must not be nil")
+		}
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of every HTTP request made by the client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		if timeout <= 0 {
+			return fmt.Errorf("paystack: timeout must be positive, got %v", timeout)
+		}
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithLogger enables logging to logger.
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) error {
+		if logger == nil {
+			return errors.New("paystack: logger must not be nil")
+		}
+		o.logger = logger
+		return nil
+	}
+}
+
+// WithSlogHandler enables structured logging of every request to h.
+// Sensitive fields are masked as configured by WithRedactionPolicy.
+func WithSlogHandler(h slog.Handler) Option {
+	return func(o *clientOptions) error {
+		if h == nil {
+			return errors.New("paystack: slog handler must not be nil")
+		}
+		o.slogLogger = slog.New(h)
+		return nil
+	}
+}
+
+// WithRedactionPolicy sets the policy used to mask sensitive fields in logs.
+func WithRedactionPolicy(policy *RedactionPolicy) Option {
+	return func(o *clientOptions) error {
+		if policy == nil {
+			return errors.New("paystack: redaction policy must not be nil")
+		}
+		o.redaction = policy
+		return nil
+	}
+}
+
+// WithUserAgentSuffix appends suffix to the User-Agent sent with every request.
+func WithUserAgentSuffix(suffix string) Option {
+	return func(o *clientOptions) error {
+		suffix = strings.TrimSpace(suffix)
+		if suffix == "" || strings.ContainsAny(suffix, "\r\n") {
+			return fmt.Errorf("paystack: invalid user agent suffix %q", suffix)
+		}
+		o.userAgentSuffix = append(o.userAgentSuffix, suffix)
+		return nil
+	}
+}
+
+// WithAppInfo identifies the application using the client in the User-Agent,
+// as "name/version (appURL)". Version and appURL are optional.
+func WithAppInfo(name, version, appURL string) Option {
+	return func(o *clientOptions) error {
+		if name == "" || strings.ContainsAny(name+version, " \t\r\n") {
+			return fmt.Errorf("paystack: invalid app name or version %q/%q", name, version)
+		}
+		info := name
+		if version != "" {
+			info += "/" + version
+		}
+		if appURL != "" {
+			info += " (" + appURL + ")"
+		}
+		return WithUserAgentSuffix(info)(o)
+	}
+}
+
+// WithRetryPolicy sets the policy for retrying failed requests.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		if policy == nil || policy.MaxAttempts < 1 {
+			return errors.New("paystack: retry policy must allow at least one attempt")
+		}
+		if policy.BaseDelay < 0 || policy.MaxDelay < 0 {
+			return errors.New("paystack: retry delays must not be negative")
+		}
+		o.retry = policy
+		return nil
+	}
+}
+
+// WithRateLimiter throttles requests made by the client with limiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *clientOptions) error {
+		if limiter == nil {
+			return errors.New("paystack: rate limiter must not be nil")
+		}
+		o.rateLimiter = limiter
+		return nil
+	}
+}
+
+// WithTracer starts a span with tracer for every API call made by the client.
+func WithTracer(tracer Tracer) Option {
+	return func(o *clientOptions) error {
+		if tracer == nil {
+			return errors.New("paystack: tracer must not be nil")
+		}
+		o.tracer = tracer
+		return nil
+	}
+}
+
+// WithMetrics records every API call made by the client with metrics.
+func WithMetrics(metrics Metrics) Option {
+	return func(o *clientOptions) error {
+		if metrics == nil {
+			return errors.New("paystack: metrics must not be nil")
+		}
+		o.metrics = metrics
+		return nil
+	}
+}
+
+// WithCircuitBreaker fails calls fast while breaker has the circuit of
+// their endpoint group open.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(o *clientOptions) error {
+		if breaker == nil {
+			return errors.New("paystack: circuit breaker must not be nil")
+		}
+		o.circuitBreaker = breaker
+		return nil
+	}
+}
+
+// WithCache caches responses of reference-data endpoints in cache, for the
+// durations in ttls keyed by operation, e.g. "Bank.List". DefaultCacheTTLs
+// is used when ttls is nil.
+func WithCache(cache Cache, ttls map[string]time.Duration) Option {
+	return func(o *clientOptions) error {
+		if cache == nil {
+			return errors.New("paystack: cache must not be nil")
+		}
+		o.cache = cache
+		o.cacheTTLs = ttls
+		return nil
+	}
+}
+
+// WithLiveKeyGuard makes NewClientWithOptions fail with ErrLiveKey when
+// given a live key, unless allowLive is true. Pass a value the deployment
+// sets explicitly, so that a live key loaded into a test job is refused:
+//
+//	paystack.WithLiveKeyGuard(os.Getenv("APP_ENV") == "production")
+func WithLiveKeyGuard(allowLive bool) Option {
+	return func(o *clientOptions) error {
+		o.liveKeyGuard = true
+		o.allowLiveKey = allowLive
+		return nil
+	}
+}
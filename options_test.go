@@ -0,0 +1,71 @@
+package paystack
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	var gotUA, gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA, gotPath = r.UserAgent(), r.URL.Path
+		w.Write([]byte(`{"status":true,"message":"ok","data":{"customer_code":"CUS_1"}}`))
+	}))
+	defer srv.Close()
+
+	hc := &http.Client{}
+	client, err := NewClientWithOptions("sk_test_key",
+		WithBaseURL(srv.URL+"/stub"),
+		WithHTTPClient(hc),
+		WithTimeout(5*time.Second),
+		WithAppInfo("checkout", "1.2.0", "https://example.com"),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+	if hc.Timeout != 0 {
+		t.Errorf("Expected caller's HTTP client to be left untouched")
+	}
+	if client.client.Timeout != 5*time.Second {
+		t.Errorf("Expected timeout of 5s, got %v", client.client.Timeout)
+	}
+
+	cust, err := client.Customer.Get("CUS_1")
+	if err != nil {
+		t.Fatalf("GET Customer returned error: %v", err)
+	}
+	if cust.CustomerCode != "CUS_1" {
+		t.Errorf("Expected customer code CUS_1, got %v", cust.CustomerCode)
+	}
+	if gotPath != "/stub/customer/CUS_1" {
+		t.Errorf("Expected request path /stub/customer/CUS_1, got %v", gotPath)
+	}
+	if want := userAgent + " checkout/1.2.0 (https://example.com)"; gotUA != want {
+		t.Errorf("Expected user agent %q, got %q", want, gotUA)
+	}
+}
+
+func TestNewClientWithOptionsValidation(t *testing.T) {
+	cases := map[string][]Option{
+		"relative base URL": {WithBaseURL("/v1")},
+		"nil HTTP client":   {WithHTTPClient(nil)},
+		"zero timeout":      {WithTimeout(0)},
+		"nil logger":        {WithLogger(nil)},
+		"bad suffix":        {WithUserAgentSuffix("a\r\nX-Injected: 1")},
+		"empty app name":    {WithAppInfo("", "1.0", "")},
+		"nil retry policy":  {WithRetryPolicy(nil)},
+	}
+	for name, opts := range cases {
+		if _, err := NewClientWithOptions("sk_test_key", opts...); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if _, err := NewClientWithOptions("", WithLogger(log.New(os.Stderr, "", 0))); err == nil {
+		t.Errorf("Expected error for empty key")
+	}
+}
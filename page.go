@@ -1,6 +1,9 @@
 package paystack
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // PageService handles operations related to the page
 // For more details see https://developers.paystack.co/v1.0/reference#create-page
@@ -10,32 +13,40 @@ type PageService service
 // For more details see https://developers.paystack.co/v1.0/reference#create-page
 type Page struct {
 	ID           int                 `json:"id,omitempty"`
-	CreatedAt    string              `json:"createdAt,omitempty"`
-	UpdatedAt    string              `json:"updatedAt,omitempty"`
+	CreatedAt    Timestamp           `json:"createdAt,omitzero"`
+	UpdatedAt    Timestamp           `json:"updatedAt,omitzero"`
 	Domain       string              `json:"domain,omitempty"`
 	Integration  int                 `json:"integration,omitempty"`
 	Name         string              `json:"name,omitempty"`
 	Slug         string              `json:"slug,omitempty"`
 	Description  string              `json:"description,omitempty"`
-	Amount       float32             `json:"amount,omitempty"`
+	Amount       Money               `json:"amount,omitzero"`
 	Currency     string              `json:"currency,omitempty"`
 	Active       bool                `json:"active,omitempty"`
 	RedirectURL  string              `json:"redirect_url,omitempty"`
 	CustomFields []map[string]string `json:"custom_fields,omitempty"`
 }
 
-// PageList is a list object for pages.
-type PageList struct {
-	Meta   ListMeta
-	Values []Page `json:"data,omitempty"`
+// UnmarshalJSON decodes p, accepting camelCase or snake_case timestamp keys.
+func (p *Page) UnmarshalJSON(data []byte) error {
+	type page Page
+	return unmarshalTimestamps(data, (*page)(p))
 }
 
+// PageList is a list object for pages.
+type PageList = List[Page]
+
 // Create creates a new page
 // For more details see https://developers.paystack.co/v1.0/reference#create-page
 func (s *PageService) Create(page *Page) (*Page, error) {
+	return s.CreateContext(context.Background(), page)
+}
+
+// CreateContext is like Create but uses ctx for the request.
+func (s *PageService) CreateContext(ctx context.Context, page *Page) (*Page, error) {
 	u := fmt.Sprintf("/page")
 	pg := &Page{}
-	err := s.client.Call("POST", u, page, pg)
+	err := s.client.call(ctx, "Page.Create", "POST", u, page, pg)
 
 	return pg, err
 }
@@ -43,9 +54,14 @@ func (s *PageService) Create(page *Page) (*Page, error) {
 // Update updates a page's properties.
 // For more details see https://developers.paystack.co/v1.0/reference#update-page
 func (s *PageService) Update(page *Page) (*Page, error) {
+	return s.UpdateContext(context.Background(), page)
+}
+
+// UpdateContext is like Update but uses ctx for the request.
+func (s *PageService) UpdateContext(ctx context.Context, page *Page) (*Page, error) {
 	u := fmt.Sprintf("page/%d", page.ID)
 	pg := &Page{}
-	err := s.client.Call("PUT", u, page, pg)
+	err := s.client.call(ctx, "Page.Update", "PUT", u, page, pg)
 
 	return pg, err
 }
@@ -53,9 +69,14 @@ func (s *PageService) Update(page *Page) (*Page, error) {
 // Get returns the details of a page.
 // For more details see https://developers.paystack.co/v1.0/reference#fetch-page
 func (s *PageService) Get(id int) (*Page, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *PageService) GetContext(ctx context.Context, id int) (*Page, error) {
 	u := fmt.Sprintf("/page/%d", id)
 	pg := &Page{}
-	err := s.client.Call("GET", u, nil, pg)
+	err := s.client.call(ctx, "Page.Get", "GET", u, nil, pg)
 
 	return pg, err
 }
@@ -63,14 +84,31 @@ func (s *PageService) Get(id int) (*Page, error) {
 // List returns a list of pages.
 // For more details see https://developers.paystack.co/v1.0/reference#list-pages
 func (s *PageService) List() (*PageList, error) {
-	return s.ListN(10, 0)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *PageService) ListContext(ctx context.Context) (*PageList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // ListN returns a list of pages
 // For more details see https://developers.paystack.co/v1.0/reference#list-pages
 func (s *PageService) ListN(count, offset int) (*PageList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *PageService) ListNContext(ctx context.Context, count, offset int) (*PageList, error) {
 	u := paginateURL("/page", count, offset)
 	pg := &PageList{}
-	err := s.client.Call("GET", u, nil, pg)
+	err := s.client.call(ctx, "Page.ListN", "GET", u, nil, pg)
 	return pg, err
 }
+
+// Iter returns an iterator over all pages, fetching perPage of them at a time.
+func (s *PageService) Iter(ctx context.Context, perPage int) *Iterator[Page] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Page], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
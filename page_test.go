@@ -3,6 +3,8 @@ package paystack
 import "testing"
 
 func TestPageCRUD(t *testing.T) {
+	useClient(t)
+
 	page1 := &Page{
 		Name:        "Demo page",
 		Description: "Paystack Go client test page",
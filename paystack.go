@@ -2,18 +2,20 @@ package paystack
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
-
-	"github.com/mitchellh/mapstructure"
 )
 
 const (
@@ -27,8 +29,7 @@ const (
 	baseURL = "https://api.paystack.co"
 
 	// User agent used when communicating with the Paystack API.
-	// userAgent = "paystack-go/" + version
-	userAgent = "Mozilla/5.0 (Unknown; Linux) AppleWebKit/538.1 (KHTML, like Gecko) Chrome/v1.0.0 Safari/538.1"
+	userAgent = "paystack-go/" + version
 )
 
 type service struct {
@@ -40,11 +41,16 @@ type Client struct {
 	common service      // Reuse a single struct instead of allocating one for each service on the heap.
 	client *http.Client // HTTP client used to communicate with the API.
 
+	mu           sync.RWMutex
+	interceptors []Interceptor
+
 	// the API Key used to authenticate all Paystack API requests
 	key string
 
 	baseURL *url.URL
 
+	userAgent string
+
 	logger Logger
 	// Services supported by the Paystack API.
 	// Miscellaneous actions are directly implemented on the Client object
@@ -67,6 +73,43 @@ type Client struct {
 
 	LoggingEnabled bool
 	Log            Logger
+
+	// Logger, when set, receives a structured record of every request with
+	// its method, path, status and latency. Request and response bodies are
+	// logged at debug level. Sensitive fields are masked by Redaction.
+	Logger *slog.Logger
+
+	// Redaction masks sensitive fields before anything is logged, either
+	// through Log or Logger. DefaultRedactionPolicy is used when it is nil.
+	Redaction *RedactionPolicy
+
+	// Retry configures automatic retries of failed requests.
+	// Requests are not retried when it is nil.
+	Retry *RetryPolicy
+
+	// RateLimiter, when set, throttles requests made by all services on the client.
+	RateLimiter *RateLimiter
+
+	// CircuitBreaker, when set, fails calls fast with ErrCircuitOpen while
+	// Paystack is failing.
+	CircuitBreaker *CircuitBreaker
+
+	// Tracer, when set, starts a span for every API call, named after the
+	// service method, e.g. paystack.Transfer.Initiate.
+	Tracer Tracer
+
+	// Metrics, when set, records the outcome and latency of every API call.
+	Metrics Metrics
+
+	// Cache, when set, stores responses of reference-data endpoints such as
+	// Bank.List, so that repeated lookups do not reach Paystack.
+	Cache Cache
+
+	// CacheTTLs maps operations, e.g. "Bank.List", to how long their
+	// responses are cached. DefaultCacheTTLs is used when it is nil.
+	CacheTTLs map[string]time.Duration
+
+	flights flightGroup
 }
 
 // Logger interface for custom loggers
@@ -99,6 +142,67 @@ type ListMeta struct {
 	PerPage   int `json:"perPage"`
 	Page      int `json:"page"`
 	PageCount int `json:"pageCount"`
+
+	// Next and Previous are the cursors of the adjacent pages in cursor
+	// pagination mode. They are empty at either end of the list.
+	Next     string `json:"next"`
+	Previous string `json:"previous"`
+}
+
+// Cursor selects a page in cursor pagination mode. Set Next or Previous
+// from the ListMeta of the current page, or leave both empty for the first
+// page. Unlike page numbers, cursors stay stable while records are created.
+type Cursor struct {
+	Next     string
+	Previous string
+}
+
+// Envelope is the JSON object wrapping every Paystack API response
+type Envelope[T any] struct {
+	Status  bool      `json:"status"`
+	Message string    `json:"message"`
+	Data    T         `json:"data"`
+	Meta    *ListMeta `json:"meta,omitempty"`
+}
+
+// List is a page of resources returned by a Paystack list endpoint
+type List[T any] struct {
+	Meta   ListMeta
+	Values []T `json:"data"`
+}
+
+// decodeEnvelope decodes a list response, including its pagination metadata
+func (l *List[T]) decodeEnvelope(body []byte) (envelopeHeader, error) {
+	env, err := decodeEnvelope(body, l.Values)
+	if env.Meta != nil {
+		l.Meta = *env.Meta
+	}
+	l.Values = env.Data
+	return env.header(), err
+}
+
+// envelopeHeader is the status and message of a response envelope
+type envelopeHeader struct {
+	Status  bool   `json:"status"`
+	Message string `json:"message"`
+}
+
+func (e *Envelope[T]) header() envelopeHeader {
+	return envelopeHeader{Status: e.Status, Message: e.Message}
+}
+
+// envelopeDecoder is implemented by result types that need more of the
+// response envelope than its data.
+type envelopeDecoder interface {
+	decodeEnvelope(body []byte) (envelopeHeader, error)
+}
+
+// decodeEnvelope decodes body in a single pass. When data is a non-nil
+// pointer held in an interface, the envelope data is decoded into it.
+func decodeEnvelope[T any](body []byte, data T) (*Envelope[T], error) {
+	env := &Envelope[T]{Data: data}
+	err := json.Unmarshal(body, env)
+	return env, err
 }
 
 // NewClient creates a new Paystack API client with the given API key
@@ -115,10 +219,17 @@ func NewClient(key string, httpClient *http.Client) *Client {
 		client:         httpClient,
 		key:            key,
 		baseURL:        u,
+		userAgent:      userAgent,
 		LoggingEnabled: true,
 		Log:            log.New(os.Stderr, "", log.LstdFlags),
 	}
+	c.initServices()
+
+	return c
+}
 
+// initServices points every service on c back at c
+func (c *Client) initServices() {
 	c.common.client = c
 	c.Customer = (*CustomerService)(&c.common)
 	c.Transaction = (*TransactionService)(&c.common)
@@ -136,23 +247,35 @@ func NewClient(key string, httpClient *http.Client) *Client {
 	c.Dispute = (*DisputeService)(&c.common)
 	c.DedicatedVirtualAccount = (*DedicatedVirtualAccountService)(&c.common)
 	c.Product = (*ProductService)(&c.common)
-
-	return c
 }
 
 // Call actually does the HTTP request to Paystack API
 func (c *Client) Call(method, path string, body, v interface{}) error {
-	var buf io.ReadWriter
+	return c.CallContext(context.Background(), method, path, body, v)
+}
+
+// CallContext does the HTTP request to Paystack API using ctx, so that
+// cancellation, deadlines and request-scoped values reach the transport.
+func (c *Client) CallContext(ctx context.Context, method, path string, body, v interface{}) error {
+	if err := c.checkKey(); err != nil {
+		return err
+	}
+
+	var payload []byte
 	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
+		body, err := requestCurrencies(body)
 		if err != nil {
 			return err
 		}
+		buf := new(bytes.Buffer)
+		err = json.NewEncoder(buf).Encode(body)
+		if err != nil {
+			return err
+		}
+		payload = buf.Bytes()
 	}
-	u, _ := c.baseURL.Parse(path)
-	req, err := http.NewRequest(method, u.String(), buf)
-
+	u, _ := c.baseURL.Parse(strings.TrimPrefix(path, "/"))
+	req, err := c.newRequest(ctx, method, u.String(), payload)
 	if err != nil {
 		if c.LoggingEnabled {
 			c.Log.Printf("Cannot create Paystack request: %v\n", err)
@@ -160,64 +283,211 @@ func (c *Client) Call(method, path string, body, v interface{}) error {
 		return err
 	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	inv := &Invocation{
+		Operation: operationFromContext(ctx),
+		Request:   req,
+		Result:    v,
 	}
-	req.Header.Set("Authorization", "Bearer "+c.key)
-	req.Header.Set("User-Agent", userAgent)
+	ctx, finish := c.instrument(ctx, inv)
+	err = c.handler()(inv)
+	finish(err)
+	if info, ok := ctx.Value(responseInfoKey{}).(*ResponseInfo); ok && inv.Response != nil {
+		*info = *inv.Response
+	}
+	return err
+}
 
-	if c.LoggingEnabled {
-		c.Log.Printf("Requesting %v %v%v\n", req.Method, req.URL.Host, req.URL.Path)
-		c.Log.Printf("POST request data %v\n", buf)
+// send is the innermost Handler. It performs the HTTP request of inv,
+// retrying as allowed by c.Retry, and decodes the response into inv.Result.
+func (c *Client) send(inv *Invocation) error {
+	ctx := inv.Request.Context()
+	payload, err := requestPayload(inv.Request)
+	if err != nil {
+		return err
 	}
+	endpoint := c.endpoint(inv.Request.URL)
+	retryable := c.Retry.allows(inv.Request.Method, payload)
+	callStart := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx, endpoint); err != nil {
+				return err
+			}
+		}
+		// the circuit is checked last, so that nothing returns between a
+		// half-open circuit letting a trial through and its outcome
+		var circuitDone func(*http.Response, error)
+		if c.CircuitBreaker != nil {
+			if circuitDone, err = c.CircuitBreaker.allow(ctx, endpoint); err != nil {
+				return err
+			}
+		}
 
-	start := time.Now()
+		req := inv.Request.Clone(ctx)
+		if payload != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(payload))
+		}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
+		if c.LoggingEnabled {
+			c.Log.Printf("Requesting %v %v%v\n", req.Method, req.URL.Host, c.redaction().RedactURL(req.URL))
+			c.Log.Printf("POST request data %s\n", c.redaction().RedactJSON(payload))
+		}
+
+		start := time.Now()
+
+		resp, err := c.client.Do(req)
+		if circuitDone != nil {
+			circuitDone(resp, err)
+		}
+		c.logAttempt(ctx, inv, req, payload, resp, time.Since(start), attempt, err)
+		if err != nil {
+			if retryable && attempt < c.Retry.MaxAttempts && isRetryableError(ctx, err) {
+				if err := c.Retry.wait(ctx, attempt, nil); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		if c.LoggingEnabled {
+			c.Log.Printf("Completed in %v\n", time.Since(start))
+		}
+
+		if retryable && attempt < c.Retry.MaxAttempts && isRetryableStatus(resp.StatusCode) {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+			if c.LoggingEnabled {
+				c.Log.Printf("Retrying %v %v after HTTP %d (attempt %d)\n", req.Method, req.URL.Path, resp.StatusCode, attempt)
+			}
+			if err := c.Retry.wait(ctx, attempt, resp); err != nil {
+				return err
+			}
+			continue
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		hdr, err := c.decodeBody(resp, body, inv.Result)
+		inv.Response = newResponseInfo(resp, body, hdr.Message, time.Since(callStart), attempt)
 		return err
 	}
+}
 
-	if c.LoggingEnabled {
-		c.Log.Printf("Completed in %v\n", time.Since(start))
+// endpoint returns the API path of u relative to the client's base URL,
+// e.g. "/bank/resolve"
+func (c *Client) endpoint(u *url.URL) string {
+	p := strings.TrimPrefix(u.Path, strings.TrimSuffix(c.baseURL.Path, "/"))
+	return "/" + strings.TrimPrefix(p, "/")
+}
+
+// requestPayload reads the body of req without consuming it
+func requestPayload(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody == nil {
+		payload, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(payload)), nil
+		}
+		req.Body, _ = req.GetBody()
+		return payload, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+// newRequest builds a Paystack API request, with payload as the JSON body
+func (c *Client) newRequest(ctx context.Context, method, rawURL string, payload []byte) (*http.Request, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
 	}
 
-	defer resp.Body.Close()
-	return c.decodeResponse(resp, v)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+c.key)
+	req.Header.Set("User-Agent", c.userAgent)
+	return req, nil
 }
 
 // ResolveCardBIN docs https://developers.paystack.co/v1.0/reference#resolve-card-bin
 func (c *Client) ResolveCardBIN(bin int) (Response, error) {
+	return c.ResolveCardBINContext(context.Background(), bin)
+}
+
+// ResolveCardBINContext is like ResolveCardBIN but uses ctx for the request.
+func (c *Client) ResolveCardBINContext(ctx context.Context, bin int) (Response, error) {
 	u := fmt.Sprintf("/decision/bin/%d", bin)
 	resp := Response{}
-	err := c.Call("GET", u, nil, &resp)
+	err := c.call(ctx, "Client.ResolveCardBIN", "GET", u, nil, &resp)
 
 	return resp, err
 }
 
 // CheckBalance docs https://developers.paystack.co/v1.0/reference#resolve-card-bin
 func (c *Client) CheckBalance() (Response, error) {
+	return c.CheckBalanceContext(context.Background())
+}
+
+// CheckBalanceContext is like CheckBalance but uses ctx for the request.
+func (c *Client) CheckBalanceContext(ctx context.Context) (Response, error) {
 	resp := Response{}
-	err := c.Call("GET", "balance", nil, &resp)
+	err := c.call(ctx, "Client.CheckBalance", "GET", "balance", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
 	// check balance 'data' node is an array
-	resp2 := resp["data"].([]interface{})[0].(map[string]interface{})
-	return resp2, err
+	balances, _ := resp["data"].([]interface{})
+	if len(balances) == 0 {
+		return nil, fmt.Errorf("paystack: balance response has no balances")
+	}
+	resp2, _ := balances[0].(map[string]interface{})
+	return resp2, nil
 }
 
 // GetSessionTimeout fetches payment session timeout
 func (c *Client) GetSessionTimeout() (Response, error) {
+	return c.GetSessionTimeoutContext(context.Background())
+}
+
+// GetSessionTimeoutContext is like GetSessionTimeout but uses ctx for the request.
+func (c *Client) GetSessionTimeoutContext(ctx context.Context) (Response, error) {
 	resp := Response{}
-	err := c.Call("GET", "/integration/payment_session_timeout", nil, &resp)
+	err := c.call(ctx, "Client.GetSessionTimeout", "GET", "/integration/payment_session_timeout", nil, &resp)
 	return resp, err
 }
 
 // UpdateSessionTimeout updates payment session timeout
 func (c *Client) UpdateSessionTimeout(timeout int) (Response, error) {
+	return c.UpdateSessionTimeoutContext(context.Background(), timeout)
+}
+
+// UpdateSessionTimeoutContext is like UpdateSessionTimeout but uses ctx for the request.
+func (c *Client) UpdateSessionTimeoutContext(ctx context.Context, timeout int) (Response, error) {
 	data := url.Values{}
 	data.Add("timeout", strconv.Itoa(timeout))
 	resp := Response{}
 	u := "/integration/payment_session_timeout"
-	err := c.Call("PUT", u, data, &resp)
+	err := c.call(ctx, "Client.UpdateSessionTimeout", "PUT", u, data, &resp)
 	return resp, err
 }
 
@@ -226,18 +496,19 @@ func paginateURL(path string, count, offset int) string {
 	return fmt.Sprintf("%s?perPage=%d&page=%d", path, count, offset)
 }
 
-func mapstruct(data interface{}, v interface{}) error {
-	config := &mapstructure.DecoderConfig{
-		Result:           v,
-		TagName:          "json",
-		WeaklyTypedInput: true,
+// cursorURL returns the URL of the page of path selected by cursor,
+// in cursor pagination mode
+func cursorURL(path string, count int, cursor Cursor) string {
+	params := url.Values{}
+	params.Set("use_cursor", "true")
+	params.Set("perPage", strconv.Itoa(count))
+	if cursor.Next != "" {
+		params.Set("next", cursor.Next)
 	}
-	decoder, err := mapstructure.NewDecoder(config)
-	if err != nil {
-		return err
+	if cursor.Previous != "" {
+		params.Set("previous", cursor.Previous)
 	}
-	err = decoder.Decode(data)
-	return err
+	return path + "?" + params.Encode()
 }
 
 func mustGetTestKey() string {
@@ -246,38 +517,95 @@ func mustGetTestKey() string {
 	if len(key) == 0 {
 		panic("PAYSTACK_KEY environment variable is not set\n")
 	}
+	if mode, _ := ParseKey(key); mode == KeyModeLive {
+		panic("PAYSTACK_KEY is a live key; tests must run with a test key\n")
+	}
 
 	return key
 }
 
-// decodeResponse decodes the JSON response from the Twitter API.
-// The actual response will be written to the `v` parameter
+// decodeResponse decodes the JSON response from the Paystack API.
+// The data of the response envelope will be written to the `v` parameter
 func (c *Client) decodeResponse(httpResp *http.Response, v interface{}) error {
-	var resp Response
 	respBody, err := ioutil.ReadAll(httpResp.Body)
-	json.Unmarshal(respBody, &resp)
+	if err != nil {
+		return err
+	}
+	_, err = c.decodeBody(httpResp, respBody, v)
+	return err
+}
 
-	if status, _ := resp["status"].(bool); !status || httpResp.StatusCode >= 400 {
-		if c.LoggingEnabled {
-			c.Log.Printf("Paystack error: %+v", err)
-			c.Log.Printf("HTTP Response: %+v", resp)
-		}
-		return newAPIError(httpResp)
+// decodeBody decodes respBody, the already-read body of httpResp, into v
+// and returns the envelope status and message
+func (c *Client) decodeBody(httpResp *http.Response, respBody []byte, v interface{}) (envelopeHeader, error) {
+	c.logResponseBody(httpResp, respBody)
+
+	var hdr envelopeHeader
+	if httpResp.StatusCode >= 400 {
+		json.Unmarshal(respBody, &hdr)
+		return hdr, c.responseError(httpResp, respBody)
+	}
+
+	var err error
+	switch t := v.(type) {
+	case envelopeDecoder:
+		hdr, err = t.decodeEnvelope(respBody)
+	case *Response:
+		hdr, err = decodeResponseMap(respBody, t)
+	default:
+		var env *Envelope[interface{}]
+		env, err = decodeEnvelope[interface{}](respBody, v)
+		hdr = env.header()
+	}
+
+	// a body that is not JSON at all, e.g. from a proxy, has no status to
+	// go by; data of an unexpected type is reported as the API error below
+	if err != nil && !json.Valid(respBody) {
+		return hdr, fmt.Errorf("paystack: decoding %s response: %w", responsePath(httpResp), err)
+	}
+	if !hdr.Status {
+		return hdr, c.responseError(httpResp, respBody)
 	}
 
 	if c.LoggingEnabled {
-		c.Log.Printf("Paystack response: %v\n", resp)
+		c.Log.Printf("Paystack response: %s\n", c.redaction().RedactJSON(respBody))
+	}
+	if err == nil {
+		setCurrencies(v)
 	}
+	return hdr, err
+}
 
-	if data, ok := resp["data"]; ok {
-		switch t := resp["data"].(type) {
-		case map[string]interface{}:
-			return mapstruct(data, v)
-		default:
-			_ = t
-			return mapstruct(resp, v)
-		}
+// responsePath returns the path of the request httpResp answers
+func responsePath(httpResp *http.Response) string {
+	if httpResp.Request == nil {
+		return "API"
+	}
+	return httpResp.Request.URL.Path
+}
+
+// decodeResponseMap decodes body into resp. Object data is unwrapped from
+// the envelope; any other response is mapped to resp in full.
+func decodeResponseMap(body []byte, resp *Response) (envelopeHeader, error) {
+	var m Response
+	if err := json.Unmarshal(body, &m); err != nil {
+		return envelopeHeader{}, err
+	}
+	var hdr envelopeHeader
+	hdr.Status, _ = m["status"].(bool)
+	hdr.Message, _ = m["message"].(string)
+	if data, ok := m["data"].(map[string]interface{}); ok {
+		*resp = data
+	} else {
+		*resp = m
+	}
+	return hdr, nil
+}
+
+func (c *Client) responseError(httpResp *http.Response, respBody []byte) error {
+	if c.LoggingEnabled {
+		c.Log.Printf("Paystack error: HTTP %d", httpResp.StatusCode)
+		c.Log.Printf("HTTP Response: %s", c.redaction().RedactJSON(respBody))
 	}
-	// if response data does not contain data key, map entire response to v
-	return mapstruct(resp, v)
+	return newAPIError(httpResp, respBody)
 }
@@ -0,0 +1,563 @@
+package paystacktest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Customers
+
+func (s *Server) createCustomer(r *request) response {
+	email := r.str("email")
+	if !strings.Contains(email, "@") {
+		return invalid("Invalid Email Address Passed")
+	}
+	if cust := s.store.find("customer", email); cust != nil {
+		return ok("Customer created", cust)
+	}
+	cust := object{
+		"email":       email,
+		"first_name":  r.str("first_name"),
+		"last_name":   r.str("last_name"),
+		"phone":       r.str("phone"),
+		"metadata":    r.body["metadata"],
+		"risk_action": "default",
+	}
+	return ok("Customer created", s.store.create("customer", cust))
+}
+
+func (s *Server) listCustomers(r *request) response {
+	return paginate(r, "Customers retrieved", s.store.all("customer", nil))
+}
+
+// getCustomer answers with the customer, its subscriptions and its
+// authorizations
+func (s *Server) getCustomer(r *request) response {
+	cust := s.store.find("customer", r.param("code"))
+	if cust == nil {
+		return notFound("Customer")
+	}
+	out := object{}
+	for k, v := range cust {
+		out[k] = v
+	}
+	out["subscriptions"] = s.store.all("subscription", func(sub object) bool {
+		return sub["customer"].(object)["id"] == cust["id"]
+	})
+	out["authorizations"] = s.customerAuthorizations(cust)
+	return ok("Customer retrieved", out)
+}
+
+func (s *Server) customerAuthorizations(cust object) []object {
+	return s.store.all("authorization", func(auth object) bool {
+		return auth["customer"] == cust["id"]
+	})
+}
+
+func (s *Server) updateCustomer(r *request) response {
+	cust := s.store.find("customer", r.param("code"))
+	if cust == nil {
+		return notFound("Customer")
+	}
+	copyFields(r, cust, "first_name", "last_name", "phone", "metadata")
+	s.store.touch("customer", cust, false)
+	return ok("Customer updated", cust)
+}
+
+func (s *Server) setRiskAction(r *request) response {
+	cust := s.store.find("customer", r.str("customer"))
+	if cust == nil {
+		return notFound("Customer")
+	}
+	action := r.str("risk_action")
+	switch action {
+	case "":
+		action = "default"
+	case "default", "allow", "deny":
+	default:
+		return invalid("Risk action must be one of default, allow or deny")
+	}
+	cust["risk_action"] = action
+	s.store.touch("customer", cust, false)
+	return ok("Customer updated", cust)
+}
+
+func (s *Server) deactivateAuthorization(r *request) response {
+	auth := s.store.find("authorization", r.str("authorization_code"))
+	if auth == nil {
+		return notFound("Authorization")
+	}
+	s.store.remove("authorization", auth)
+	return ok("Authorization has been deactivated", nil)
+}
+
+// Plans
+
+var planIntervals = map[string]bool{
+	"hourly": true, "daily": true, "weekly": true, "monthly": true,
+	"quarterly": true, "biannually": true, "annually": true,
+}
+
+func (s *Server) createPlan(r *request) response {
+	switch {
+	case r.str("name") == "":
+		return invalid("Plan name is required")
+	case r.int("amount") <= 0:
+		return invalid("Invalid amount")
+	case !planIntervals[r.str("interval")]:
+		return invalid("Invalid interval")
+	}
+	plan := object{
+		"name":          r.str("name"),
+		"amount":        r.int("amount"),
+		"interval":      r.str("interval"),
+		"currency":      currency(r),
+		"send_invoices": true,
+		"send_sms":      true,
+	}
+	copyFields(r, plan, "description", "send_invoices", "send_sms", "invoice_limit")
+	return created("Plan created", s.store.create("plan", plan))
+}
+
+func (s *Server) listPlans(r *request) response {
+	plans := s.store.all("plan", func(plan object) bool {
+		return matches(plan, "interval", r.query("interval")) &&
+			matches(plan, "amount", r.query("amount"))
+	})
+	return paginate(r, "Plans retrieved", plans)
+}
+
+func (s *Server) getPlan(r *request) response {
+	plan := s.store.find("plan", r.param("code"))
+	if plan == nil {
+		return notFound("Plan")
+	}
+	out := object{}
+	for k, v := range plan {
+		out[k] = v
+	}
+	out["subscriptions"] = s.store.all("subscription", func(sub object) bool {
+		return sub["plan"].(object)["id"] == plan["id"]
+	})
+	return ok("Plan retrieved", out)
+}
+
+func (s *Server) updatePlan(r *request) response {
+	plan := s.store.find("plan", r.param("code"))
+	if plan == nil {
+		return notFound("Plan")
+	}
+	if r.has("interval") && !planIntervals[r.str("interval")] {
+		return invalid("Invalid interval")
+	}
+	copyFields(r, plan, "name", "description", "interval", "send_invoices", "send_sms", "invoice_limit", "currency")
+	if r.has("amount") {
+		plan["amount"] = r.int("amount")
+	}
+	s.store.touch("plan", plan, false)
+	subs := s.store.all("subscription", func(sub object) bool {
+		return sub["plan"].(object)["id"] == plan["id"]
+	})
+	return ok(fmt.Sprintf("Plan updated. %d subscription(s) will be affected", len(subs)), nil)
+}
+
+// Subscriptions
+
+func (s *Server) createSubscription(r *request) response {
+	cust := s.store.find("customer", r.str("customer"))
+	if cust == nil {
+		return notFound("Customer")
+	}
+	plan := s.store.find("plan", r.str("plan"))
+	if plan == nil {
+		return notFound("Plan")
+	}
+	auth := s.store.find("authorization", r.str("authorization"))
+	if auth == nil && r.str("authorization") == "" {
+		if auths := s.customerAuthorizations(cust); len(auths) > 0 {
+			auth = auths[0]
+		}
+	}
+	if auth == nil {
+		return invalid("This customer has no saved authorizations")
+	}
+	sub := s.subscribe(cust, plan, auth)
+	if start := r.str("start"); start != "" {
+		sub["start"] = start
+		sub["next_payment_date"] = start
+	}
+	return ok("Subscription successfully created", sub)
+}
+
+// subscribe creates an active subscription of cust to plan
+func (s *Server) subscribe(cust, plan, auth object) object {
+	sub := s.store.create("subscription", object{
+		"customer":          cust,
+		"plan":              plan,
+		"authorization":     auth,
+		"status":            "active",
+		"quantity":          1,
+		"amount":            plan["amount"],
+		"start":             now(),
+		"next_payment_date": now(),
+		"cron_expression":   "0 0 * * *",
+		"invoices":          []object{},
+	})
+	sub["email_token"] = code("", sub["id"].(int)*31)
+	return sub
+}
+
+func (s *Server) listSubscriptions(r *request) response {
+	subs := s.store.all("subscription", func(sub object) bool {
+		return matches(sub, "customer", r.query("customer")) &&
+			matches(sub, "plan", r.query("plan"))
+	})
+	return paginate(r, "Subscriptions retrieved", subs)
+}
+
+func (s *Server) getSubscription(r *request) response {
+	sub := s.store.find("subscription", r.param("code"))
+	if sub == nil {
+		return notFound("Subscription")
+	}
+	return ok("Subscription retrieved successfully", sub)
+}
+
+func (s *Server) updateSubscription(r *request) response {
+	sub := s.store.find("subscription", r.param("code"))
+	if sub == nil {
+		return notFound("Subscription")
+	}
+	if code := r.str("authorization"); code != "" {
+		auth := s.store.find("authorization", code)
+		if auth == nil {
+			return notFound("Authorization")
+		}
+		sub["authorization"] = auth
+	}
+	if start := r.str("start"); start != "" {
+		sub["next_payment_date"] = start
+	}
+	s.store.touch("subscription", sub, false)
+	return ok("Subscription updated", sub)
+}
+
+// setSubscriptionStatus returns a handler enabling or disabling the
+// subscription whose code and email token are in the body
+func (s *Server) setSubscriptionStatus(status string) handler {
+	return func(r *request) response {
+		sub := s.store.find("subscription", r.str("code"))
+		if sub == nil || sub["email_token"] != r.str("token") {
+			return notFound("Subscription")
+		}
+		if sub["status"] == status {
+			return invalid("Subscription is already " + status)
+		}
+		sub["status"] = status
+		s.store.touch("subscription", sub, false)
+		if status == "active" {
+			return ok("Subscription enabled successfully", nil)
+		}
+		return ok("Subscription disabled successfully", nil)
+	}
+}
+
+// Pages
+
+func (s *Server) createPage(r *request) response {
+	if r.str("name") == "" {
+		return invalid("Page name is required")
+	}
+	slug := r.str("slug")
+	if slug == "" {
+		slug = slugify(r.str("name"))
+		if s.store.find("page", slug) != nil {
+			slug = fmt.Sprintf("%s-%d", slug, s.store.nextID)
+		}
+	} else if s.store.find("page", slug) != nil {
+		return invalid("Slug is already taken")
+	}
+	page := object{
+		"name":     r.str("name"),
+		"slug":     slug,
+		"currency": currency(r),
+		"active":   true,
+	}
+	copyFields(r, page, "description", "redirect_url", "custom_fields")
+	if r.has("amount") {
+		page["amount"] = r.int("amount")
+	}
+	return ok("Page created", s.store.create("page", page))
+}
+
+func slugify(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+func (s *Server) listPages(r *request) response {
+	return paginate(r, "Pages retrieved", s.store.all("page", nil))
+}
+
+func (s *Server) getPage(r *request) response {
+	page := s.store.find("page", r.param("slug"))
+	if page == nil {
+		return notFound("Page")
+	}
+	return ok("Page retrieved", page)
+}
+
+func (s *Server) updatePage(r *request) response {
+	page := s.store.find("page", r.param("slug"))
+	if page == nil {
+		return notFound("Page")
+	}
+	copyFields(r, page, "name", "description", "active", "redirect_url", "custom_fields")
+	if r.has("amount") {
+		page["amount"] = r.int("amount")
+	}
+	s.store.touch("page", page, false)
+	return ok("Page updated", page)
+}
+
+// Products
+
+func (s *Server) createProduct(r *request) response {
+	switch {
+	case r.str("name") == "":
+		return invalid("Product name is required")
+	case r.str("description") == "":
+		return invalid("Product description is required")
+	case r.int("price") <= 0:
+		return invalid("Invalid price")
+	}
+	product := object{
+		"name":          r.str("name"),
+		"description":   r.str("description"),
+		"price":         r.int("price"),
+		"currency":      currency(r),
+		"unlimited":     r.bool("unlimited"),
+		"quantity":      r.int("quantity"),
+		"quantity_sold": 0,
+		"type":          "good",
+		"active":        true,
+		"in_stock":      true,
+	}
+	product = s.store.create("product", product)
+	product["slug"] = fmt.Sprintf("%s-%d", slugify(r.str("name")), product["id"])
+	return created("Product successfully created", product)
+}
+
+func (s *Server) listProducts(r *request) response {
+	return paginate(r, "Products retrieved", s.store.all("product", nil))
+}
+
+func (s *Server) getProduct(r *request) response {
+	product := s.store.find("product", r.param("id"))
+	if product == nil {
+		return notFound("Product")
+	}
+	return ok("Product retrieved", product)
+}
+
+func (s *Server) updateProduct(r *request) response {
+	product := s.store.find("product", r.param("id"))
+	if product == nil {
+		return notFound("Product")
+	}
+	copyFields(r, product, "name", "description", "currency", "unlimited")
+	for _, k := range []string{"price", "quantity"} {
+		if r.has(k) {
+			product[k] = r.int(k)
+		}
+	}
+	s.store.touch("product", product, false)
+	return ok("Product successfully updated", product)
+}
+
+// Subaccounts
+
+func (s *Server) createSubAccount(r *request) response {
+	switch {
+	case r.str("business_name") == "":
+		return invalid("Business name is required")
+	case bankByCode(r.str("settlement_bank")) == nil:
+		return invalid("Invalid settlement bank")
+	case len(r.str("account_number")) != 10:
+		return invalid("Invalid account number")
+	}
+	acct := object{
+		"business_name":       r.str("business_name"),
+		"settlement_bank":     bankByCode(r.str("settlement_bank"))["name"],
+		"account_number":      r.str("account_number"),
+		"percentage_charge":   r.body["percentage_charge"],
+		"settlement_schedule": "AUTO",
+		"is_verified":         false,
+		"active":              true,
+		"migrate":             false,
+	}
+	copyFields(r, acct, "description", "primary_contact_name", "primary_contact_email",
+		"primary_contact_phone", "metadata", "settlement_schedule")
+	return created("Subaccount created", s.store.create("subaccount", acct))
+}
+
+func (s *Server) listSubAccounts(r *request) response {
+	return paginate(r, "Subaccounts retrieved", s.store.all("subaccount", nil))
+}
+
+func (s *Server) getSubAccount(r *request) response {
+	acct := s.store.find("subaccount", r.param("code"))
+	if acct == nil {
+		return notFound("Subaccount")
+	}
+	return ok("Subaccount retrieved", acct)
+}
+
+func (s *Server) updateSubAccount(r *request) response {
+	acct := s.store.find("subaccount", r.param("code"))
+	if acct == nil {
+		return notFound("Subaccount")
+	}
+	if r.has("settlement_bank") {
+		bank := bankByCode(r.str("settlement_bank"))
+		if bank == nil {
+			return invalid("Invalid settlement bank")
+		}
+		acct["settlement_bank"] = bank["name"]
+	}
+	copyFields(r, acct, "business_name", "description", "account_number", "active",
+		"percentage_charge", "primary_contact_name", "primary_contact_email",
+		"primary_contact_phone", "metadata", "settlement_schedule")
+	s.store.touch("subaccount", acct, false)
+	return ok("Subaccount updated", acct)
+}
+
+// Splits
+
+func (s *Server) createSplit(r *request) response {
+	switch r.str("type") {
+	case "percentage", "flat":
+	default:
+		return invalid("Split type must be percentage or flat")
+	}
+	if r.str("name") == "" {
+		return invalid("Split name is required")
+	}
+	entries, _ := r.body["subaccounts"].([]interface{})
+	if len(entries) == 0 {
+		return invalid("Subaccounts are required")
+	}
+	var subaccounts []object
+	for _, e := range entries {
+		m, _ := e.(map[string]interface{})
+		acct := s.store.find("subaccount", toString(m["subaccount"]))
+		if acct == nil {
+			return notFound("Subaccount")
+		}
+		share, _ := m["share"].(float64)
+		subaccounts = append(subaccounts, object{"subaccount": acct, "share": int(share)})
+	}
+	bearer := r.str("bearer_type")
+	if bearer == "" {
+		bearer = "all"
+	}
+	split := object{
+		"name":              r.str("name"),
+		"type":              r.str("type"),
+		"currency":          currency(r),
+		"active":            true,
+		"is_dynamic":        false,
+		"bearer_type":       bearer,
+		"bearer_subaccount": r.str("bearer_subaccount"),
+		"subaccounts":       subaccounts,
+		"total_subaccounts": len(subaccounts),
+	}
+	return ok("Split created", s.store.create("split", split))
+}
+
+func (s *Server) listSplits(r *request) response {
+	splits := s.store.all("split", func(split object) bool {
+		return matches(split, "name", r.query("name")) &&
+			matches(split, "active", r.query("active"))
+	})
+	return paginate(r, "Split retrieved", splits)
+}
+
+func (s *Server) getSplit(r *request) response {
+	split := s.store.find("split", r.param("id"))
+	if split == nil {
+		return notFound("Split")
+	}
+	return ok("Split retrieved", split)
+}
+
+func (s *Server) updateSplit(r *request) response {
+	split := s.store.find("split", r.param("id"))
+	if split == nil {
+		return notFound("Split")
+	}
+	copyFields(r, split, "name", "active", "bearer_type", "bearer_subaccount")
+	s.store.touch("split", split, false)
+	return ok("Split group updated", split)
+}
+
+func (s *Server) addSplitSubAccount(r *request) response {
+	split := s.store.find("split", r.param("id"))
+	if split == nil {
+		return notFound("Split")
+	}
+	acct := s.store.find("subaccount", r.str("subaccount"))
+	if acct == nil {
+		return notFound("Subaccount")
+	}
+	subaccounts := split["subaccounts"].([]object)
+	share := int(r.int("share"))
+	found := false
+	for _, sa := range subaccounts {
+		if sa["subaccount"].(object)["id"] == acct["id"] {
+			sa["share"], found = share, true
+		}
+	}
+	if !found {
+		subaccounts = append(subaccounts, object{"subaccount": acct, "share": share})
+	}
+	split["subaccounts"], split["total_subaccounts"] = subaccounts, len(subaccounts)
+	s.store.touch("split", split, false)
+	return ok("Subaccount added", split)
+}
+
+func (s *Server) removeSplitSubAccount(r *request) response {
+	split := s.store.find("split", r.param("id"))
+	if split == nil {
+		return notFound("Split")
+	}
+	acct := s.store.find("subaccount", r.str("subaccount"))
+	if acct == nil {
+		return notFound("Subaccount")
+	}
+	var kept []object
+	for _, sa := range split["subaccounts"].([]object) {
+		if sa["subaccount"].(object)["id"] != acct["id"] {
+			kept = append(kept, sa)
+		}
+	}
+	split["subaccounts"], split["total_subaccounts"] = kept, len(kept)
+	s.store.touch("split", split, false)
+	return ok("Subaccount removed", split)
+}
+
+// currency returns the currency of the request, NGN by default
+func currency(r *request) string {
+	if c := r.str("currency"); c != "" {
+		return c
+	}
+	return "NGN"
+}
@@ -0,0 +1,205 @@
+package paystacktest
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault is a way the server misbehaves when answering a request, for
+// testing how clients cope with Paystack failing. Faults are scripted per
+// route with Server.Inject.
+type Fault struct {
+	// Latency delays the response. With no other field set, the request
+	// is then answered normally.
+	Latency time.Duration
+
+	// Status answers with an error envelope with this HTTP status.
+	Status int
+	// RetryAfter sets the Retry-After header of the response.
+	RetryAfter time.Duration
+
+	// Body answers with this raw body, with Status or 200 OK.
+	Body string
+
+	// Drop closes the connection without answering.
+	Drop bool
+
+	// count is the number of requests the fault applies to, or -1 for
+	// every request
+	count int
+}
+
+// Delay returns a fault answering requests normally after d.
+func Delay(d time.Duration) Fault {
+	return Fault{Latency: d}
+}
+
+// RateLimited returns a fault answering 429 Too Many Requests with a
+// Retry-After header of retryAfter.
+func RateLimited(retryAfter time.Duration) Fault {
+	return Fault{Status: http.StatusTooManyRequests, RetryAfter: retryAfter}
+}
+
+// ServerError returns a fault answering with the 5xx status.
+func ServerError(status int) Fault {
+	return Fault{Status: status}
+}
+
+// MalformedJSON returns a fault answering 200 OK with a truncated JSON body.
+func MalformedJSON() Fault {
+	return Fault{Body: `{"status":true,"message":"Customer retrieved","data":{"id":`}
+}
+
+// StatusFalse returns a fault answering 200 OK with an envelope whose
+// status is false, as Paystack does for some failures.
+func StatusFalse(message string) Fault {
+	return Fault{Body: `{"status":false,"message":` + strconv.Quote(message) + `}`}
+}
+
+// DropConnection returns a fault closing the connection without answering.
+func DropConnection() Fault {
+	return Fault{Drop: true}
+}
+
+// Times returns f applying to n consecutive requests instead of one.
+func (f Fault) Times(n int) Fault {
+	f.count = n
+	return f
+}
+
+// Always returns f applying to every request.
+func (f Fault) Always() Fault {
+	f.count = -1
+	return f
+}
+
+// faultScript is the faults injected into the requests matching a route
+type faultScript struct {
+	route  route
+	faults []Fault
+	used   int // requests the first fault has applied to
+}
+
+// Inject scripts faults for the requests whose method and path match
+// method and pattern. Patterns are paths whose segments may be
+// placeholders such as {id}, e.g. /transaction/verify/{reference}.
+// An empty method matches every method.
+//
+// The faults apply in order, each to one request unless set otherwise
+// with Times or Always; once they are used up, requests are answered
+// normally again. For example, a burst of three server errors:
+//
+//	srv.Inject("POST", "/transfer", paystacktest.ServerError(503).Times(3))
+func (s *Server) Inject(method, pattern string, faults ...Fault) {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	s.faults = append(s.faults, &faultScript{route: newRoute(method, pattern, nil), faults: faults})
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	s.faults = nil
+}
+
+// Calls returns the number of requests received, including those answered
+// with a fault, whose method and path match method and pattern as for
+// Inject.
+func (s *Server) Calls(method, pattern string) int {
+	rt := newRoute(method, pattern, nil)
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	n := 0
+	for _, c := range s.calls {
+		if rt.matchRequest(c.method, c.path) {
+			n++
+		}
+	}
+	return n
+}
+
+// call is a request received by the server
+type call struct {
+	method, path string
+}
+
+// nextFault records the request r and returns the fault to answer it with
+func (s *Server) nextFault(r *http.Request) (Fault, bool) {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	s.calls = append(s.calls, call{r.Method, r.URL.Path})
+	for _, fs := range s.faults {
+		if len(fs.faults) == 0 || !fs.route.matchRequest(r.Method, r.URL.Path) {
+			continue
+		}
+		f := fs.faults[0]
+		fs.used++
+		if f.count >= 0 && fs.used >= max(f.count, 1) {
+			fs.faults, fs.used = fs.faults[1:], 0
+		}
+		return f, true
+	}
+	return Fault{}, false
+}
+
+func (rt route) matchRequest(method, path string) bool {
+	if rt.method != "" && rt.method != method {
+		return false
+	}
+	_, ok := rt.match(strings.Split(strings.Trim(path, "/"), "/"))
+	return ok
+}
+
+// serveFault answers r with f. It reports false if the request should
+// then be answered normally.
+func serveFault(w http.ResponseWriter, r *http.Request, f Fault) bool {
+	if f.Latency > 0 {
+		t := time.NewTimer(f.Latency)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	switch {
+	case f.Drop:
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				conn.Close()
+				return true
+			}
+		}
+		panic(http.ErrAbortHandler)
+	case f.Status == 0 && f.Body == "":
+		return false
+	}
+
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(f.RetryAfter.Seconds()))))
+	}
+	status := f.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if f.Body != "" {
+		w.Write([]byte(f.Body))
+		return true
+	}
+	writeEnvelope(w, fail(status, faultMessage(status)))
+	return true
+}
+
+func faultMessage(status int) string {
+	if status == http.StatusTooManyRequests {
+		return "Too many requests. Please try again later"
+	}
+	return http.StatusText(status)
+}
@@ -0,0 +1,92 @@
+package paystacktest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// send sends a request for path on api.paystack.co through srv.Client,
+// and returns the response with its raw body.
+func send(t *testing.T, ctx context.Context, srv *Server, method, path string) (*http.Response, string, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, method, "https://api.paystack.co"+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+SecretKey)
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return resp, string(body), err
+}
+
+func TestInjectScript(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	srv.Inject("GET", "/customer/{code}",
+		ServerError(503).Times(2),
+		RateLimited(3*time.Second),
+		StatusFalse("Customer lookup failed"),
+		MalformedJSON(),
+	)
+
+	for i := 0; i < 2; i++ {
+		resp, _, err := send(t, ctx, srv, "GET", "/customer/CUS_x")
+		if err != nil || resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("request %d: got %v %v, want 503", i, resp, err)
+		}
+	}
+	resp, _, _ := send(t, ctx, srv, "GET", "/customer/CUS_x")
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "3" {
+		t.Errorf("got %d with Retry-After %q, want 429 with 3", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+	resp, body, _ := send(t, ctx, srv, "GET", "/customer/CUS_x")
+	if resp.StatusCode != http.StatusOK || body != `{"status":false,"message":"Customer lookup failed"}` {
+		t.Errorf("got %d %s, want a 200 with status false", resp.StatusCode, body)
+	}
+	if _, body, _ := send(t, ctx, srv, "GET", "/customer/CUS_x"); body != MalformedJSON().Body {
+		t.Errorf("got %s, want malformed JSON", body)
+	}
+
+	// the script is used up
+	if resp, _, _ := send(t, ctx, srv, "GET", "/customer/CUS_x"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("got %d, want the normal 404", resp.StatusCode)
+	}
+	// other routes are untouched
+	if resp, _, _ := send(t, ctx, srv, "GET", "/customer"); resp.StatusCode != http.StatusOK {
+		t.Errorf("got %d, want 200", resp.StatusCode)
+	}
+	if n := srv.Calls("GET", "/customer/{code}"); n != 6 {
+		t.Errorf("got %d calls, want 6", n)
+	}
+}
+
+func TestInjectDropAndLatency(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Inject("", "/bank", DropConnection())
+	if _, _, err := send(t, context.Background(), srv, "GET", "/bank"); err == nil {
+		t.Error("expected an error for a dropped connection")
+	}
+
+	srv.Inject("", "/bank", Delay(time.Second).Always())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := send(t, ctx, srv, "GET", "/bank"); err == nil {
+		t.Error("expected the request to time out")
+	}
+
+	srv.ClearFaults()
+	if resp, _, err := send(t, context.Background(), srv, "GET", "/bank"); err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("got %v %v, want 200 once faults are cleared", resp, err)
+	}
+}
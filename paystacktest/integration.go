@@ -0,0 +1,322 @@
+package paystacktest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// banks are the banks known to the server. Account numbers of 10 digits
+// resolve at any of them.
+var banks = []object{
+	{"id": 1, "name": "Access Bank", "slug": "access-bank", "code": "044", "longcode": "044150149"},
+	{"id": 2, "name": "First Bank of Nigeria", "slug": "first-bank-of-nigeria", "code": "011", "longcode": "011151003"},
+	{"id": 3, "name": "Guaranty Trust Bank", "slug": "guaranty-trust-bank", "code": "058", "longcode": "058152036"},
+	{"id": 4, "name": "United Bank For Africa", "slug": "united-bank-for-africa", "code": "033", "longcode": "033153513"},
+	{"id": 5, "name": "Wema Bank", "slug": "wema-bank", "code": "035", "longcode": "035150103"},
+	{"id": 6, "name": "Zenith Bank", "slug": "zenith-bank", "code": "057", "longcode": "057150013"},
+	{"id": 7, "name": "Test Bank", "slug": "test-bank", "code": "001", "longcode": "001000000"},
+}
+
+func init() {
+	for _, b := range banks {
+		b["gateway"] = "emandate"
+		b["pay_with_bank"] = false
+		b["active"] = true
+		b["is_deleted"] = false
+		b["country"] = "Nigeria"
+		b["currency"] = "NGN"
+		b["type"] = "nuban"
+	}
+}
+
+func bankByCode(code string) object {
+	for _, b := range banks {
+		if b["code"] == code {
+			return b
+		}
+	}
+	return nil
+}
+
+// dvaProviders are the banks dedicated virtual accounts can be created at
+var dvaProviders = []object{
+	{"provider_slug": "wema-bank", "bank_id": 5, "bank_name": "Wema Bank", "id": 1},
+	{"provider_slug": "test-bank", "bank_id": 7, "bank_name": "Test Bank", "id": 2},
+}
+
+func dvaProvider(slug string) object {
+	for _, p := range dvaProviders {
+		if p["provider_slug"] == slug {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) listBanks(r *request) response {
+	return ok("Banks retrieved", banks)
+}
+
+func (s *Server) resolveAccountNumber(r *request) response {
+	number := r.query("account_number")
+	bank := bankByCode(r.query("bank_code"))
+	if len(number) != 10 || bank == nil {
+		return fail(422, "Could not resolve account name. Check parameters or try again.")
+	}
+	return ok("Account number resolved", object{
+		"account_number": number,
+		"account_name":   "TEST ACCOUNT " + number[6:],
+		"bank_id":        bank["id"],
+	})
+}
+
+func (s *Server) resolveBVN(r *request) response {
+	bvn := r.param("bvn")
+	if len(bvn) != 11 {
+		return invalid("Invalid BVN")
+	}
+	return ok("BVN resolved", object{
+		"bvn":           bvn,
+		"first_name":    "TEST",
+		"last_name":     "CUSTOMER",
+		"dob":           "01-Jan-90",
+		"formatted_dob": "1990-01-01",
+		"mobile":        "08000000000",
+		"meta": object{
+			"calls_this_month": 1,
+			"free_calls_left":  9,
+		},
+	})
+}
+
+func (s *Server) resolveCardBIN(r *request) response {
+	bin := r.param("bin")
+	if len(bin) < 5 || strings.Trim(bin, "0123456789") != "" {
+		return invalid("Invalid BIN")
+	}
+	brand := "visa"
+	if strings.HasPrefix(bin, "5") {
+		brand = "mastercard"
+	}
+	return ok("Bin resolved", object{
+		"bin":            bin,
+		"brand":          brand,
+		"sub_brand":      "",
+		"country_code":   "NG",
+		"country_name":   "Nigeria",
+		"card_type":      "DEBIT",
+		"bank":           "TEST BANK",
+		"linked_bank_id": 7,
+	})
+}
+
+func (s *Server) getSessionTimeout(r *request) response {
+	return ok("Payment session timeout retrieved", object{"payment_session_timeout": s.store.sessionTimeout})
+}
+
+func (s *Server) updateSessionTimeout(r *request) response {
+	timeout := r.int("timeout")
+	if timeout < 0 {
+		return invalid("Timeout must not be negative")
+	}
+	s.store.sessionTimeout = int(timeout)
+	return ok("Payment session timeout updated", object{"payment_session_timeout": s.store.sessionTimeout})
+}
+
+func (s *Server) listSettlements(r *request) response {
+	return paginate(r, "Settlements retrieved", s.store.all("settlement", nil))
+}
+
+// Dedicated virtual accounts
+
+// createDVA creates a dedicated virtual account for an existing customer.
+// Requests with an email address are assignments, which create the
+// customer too.
+func (s *Server) createDVA(r *request) response {
+	if r.has("email") {
+		return s.assignDVA(r)
+	}
+	cust := s.store.find("customer", r.str("customer"))
+	if cust == nil {
+		return notFound("Customer")
+	}
+	dva, resp := s.dedicateAccount(r, cust)
+	if dva == nil {
+		return resp
+	}
+	return ok("Assigned Managed Account Successfully Created", dva)
+}
+
+func (s *Server) assignDVA(r *request) response {
+	email := r.str("email")
+	switch {
+	case !strings.Contains(email, "@"):
+		return invalid("Invalid Email Address Passed")
+	case r.str("first_name") == "" || r.str("last_name") == "":
+		return invalid("First and last name are required")
+	case r.str("phone") == "":
+		return invalid("Phone is required")
+	case r.str("country") != "" && r.str("country") != "NG":
+		return invalid("Dedicated accounts are only available in NG")
+	}
+	cust := s.customerByEmail(email)
+	copyFields(r, cust, "first_name", "last_name", "phone")
+	dva, resp := s.dedicateAccount(r, cust)
+	if dva == nil {
+		return resp
+	}
+	return ok("Assign dedicated account in progress", dva)
+}
+
+// dedicateAccount returns the active account of cust at the preferred
+// bank of the request, creating it if needed, with the split of the
+// request applied.
+func (s *Server) dedicateAccount(r *request, cust object) (object, response) {
+	slug := r.str("preferred_bank")
+	if slug == "" {
+		slug = "test-bank"
+	}
+	provider := dvaProvider(slug)
+	if provider == nil {
+		return nil, invalid("Invalid preferred bank")
+	}
+	split, resp := s.dvaSplit(r)
+	if resp.status != 0 {
+		return nil, resp
+	}
+
+	dva := s.customerDVA(cust, slug)
+	if dva == nil {
+		dva = s.store.create("dva", object{
+			"bank": object{
+				"id":   provider["bank_id"],
+				"name": provider["bank_name"],
+				"slug": slug,
+			},
+			"account_name": strings.ToUpper(strings.TrimSpace(fmt.Sprintf("PAYSTACKTEST/%v %v", cust["first_name"], cust["last_name"]))),
+			"assigned":     true,
+			"currency":     "NGN",
+			"metadata":     nil,
+			"active":       true,
+			"customer":     cust,
+		})
+		dva["account_number"] = fmt.Sprintf("99%08d", dva["id"])
+		dva["assignment"] = object{
+			"integration":   integrationID,
+			"assignee_id":   cust["id"],
+			"assignee_type": "Customer",
+			"expired":       false,
+			"account_type":  "PAY-WITH-TRANSFER-RECURRING",
+			"assigned_at":   dva["created_at"],
+		}
+	}
+	if split != nil {
+		dva["split_config"] = split
+		s.store.touch("dva", dva, false)
+	}
+	return dva, response{}
+}
+
+// dvaSplit returns the split named by the split_code or subaccount of the
+// request, if any. Subaccounts are turned into a split of their own.
+func (s *Server) dvaSplit(r *request) (object, response) {
+	if code := r.str("split_code"); code != "" {
+		split := s.store.find("split", code)
+		if split == nil {
+			return nil, notFound("Split")
+		}
+		return split, response{}
+	}
+	if code := r.str("subaccount"); code != "" {
+		acct := s.store.find("subaccount", code)
+		if acct == nil {
+			return nil, notFound("Subaccount")
+		}
+		return s.store.create("split", object{
+			"name":              "Dedicated account split",
+			"type":              "percentage",
+			"currency":          "NGN",
+			"active":            true,
+			"is_dynamic":        true,
+			"bearer_type":       "account",
+			"subaccounts":       []object{{"subaccount": acct, "share": acct["percentage_charge"]}},
+			"total_subaccounts": 1,
+		}), response{}
+	}
+	return nil, response{}
+}
+
+// customerDVA returns the active account of cust at the bank with slug
+func (s *Server) customerDVA(cust object, slug string) object {
+	for _, dva := range s.store.all("dva", nil) {
+		if dva["customer"].(object)["id"] == cust["id"] && dva["active"] == true &&
+			dva["bank"].(object)["slug"] == slug {
+			return dva
+		}
+	}
+	return nil
+}
+
+func (s *Server) listDVAs(r *request) response {
+	dvas := s.store.all("dva", func(dva object) bool {
+		bank := dva["bank"].(object)
+		return matches(dva, "active", r.query("active")) &&
+			matches(dva, "currency", r.query("currency")) &&
+			matches(dva, "customer", r.query("customer")) &&
+			matches(bank, "slug", r.query("provider_slug")) &&
+			matches(bank, "id", r.query("bank_id"))
+	})
+	return paginate(r, "Managed Accounts Successfully Retrieved", dvas)
+}
+
+func (s *Server) getDVA(r *request) response {
+	dva := s.store.find("dva", r.param("id"))
+	if dva == nil {
+		return notFound("Dedicated account")
+	}
+	return ok("Customer retrieved", dva)
+}
+
+func (s *Server) requeryDVA(r *request) response {
+	dva := s.store.find("dva", r.query("account_number"))
+	if dva == nil || dva["bank"].(object)["slug"] != r.query("provider_slug") {
+		return notFound("Dedicated account")
+	}
+	return ok("We are checking the status of your transfer. We will send you a notification once it is confirmed", nil)
+}
+
+func (s *Server) deactivateDVA(r *request) response {
+	dva := s.store.find("dva", r.param("id"))
+	if dva == nil {
+		return notFound("Dedicated account")
+	}
+	dva["active"], dva["assigned"] = false, false
+	s.store.touch("dva", dva, false)
+	return ok("Managed Account Successfully Unassigned", dva)
+}
+
+func (s *Server) splitDVA(r *request) response {
+	cust := s.store.find("customer", r.str("customer"))
+	if cust == nil {
+		return notFound("Customer")
+	}
+	dva, resp := s.dedicateAccount(r, cust)
+	if dva == nil {
+		return resp
+	}
+	return ok("Assigned Managed Account Successfully Created", dva)
+}
+
+func (s *Server) removeDVASplit(r *request) response {
+	dva := s.store.find("dva", r.str("account_number"))
+	if dva == nil {
+		return notFound("Dedicated account")
+	}
+	delete(dva, "split_config")
+	s.store.touch("dva", dva, false)
+	return ok("Subaccount unassigned", dva)
+}
+
+func (s *Server) listDVAProviders(r *request) response {
+	return ok("Available bank providers retrieved", dvaProviders)
+}
@@ -0,0 +1,742 @@
+package paystacktest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TestOTP is the OTP accepted for charges and transfers awaiting one.
+const TestOTP = "123456"
+
+// Transactions
+
+func (s *Server) initializeTransaction(r *request) response {
+	email := r.str("email")
+	if !strings.Contains(email, "@") {
+		return invalid("Invalid Email Address Passed")
+	}
+	amount := r.int("amount")
+	var plan object
+	if code := r.str("plan"); code != "" {
+		if plan = s.store.find("plan", code); plan == nil {
+			return notFound("Plan")
+		}
+		amount = plan["amount"].(int64)
+	}
+	if amount <= 0 {
+		return invalid("Invalid Amount Sent")
+	}
+	ref, resp, fresh := s.newReference(r)
+	if !fresh {
+		return resp
+	}
+
+	txn := s.newTransaction(s.customerByEmail(email), ref, amount, currency(r))
+	txn["metadata"] = r.body["metadata"]
+	if plan != nil {
+		txn["plan"] = plan
+	}
+	if code := r.str("subaccount"); code != "" {
+		if acct := s.store.find("subaccount", code); acct != nil {
+			txn["subaccount"] = acct
+		}
+	}
+	accessCode := code("", txn["id"].(int)*13)
+	return ok("Authorization URL created", object{
+		"authorization_url": "https://checkout.paystack.com/" + accessCode,
+		"access_code":       accessCode,
+		"reference":         ref,
+	})
+}
+
+// newReference returns the reference of a new transaction: the one in
+// the request, which must not be in use, or a generated one.
+func (s *Server) newReference(r *request) (string, response, bool) {
+	ref := r.str("reference")
+	if ref == "" {
+		return fmt.Sprintf("T%09d", s.store.nextID), response{}, true
+	}
+	if s.store.find("transaction", ref) != nil {
+		return "", invalid("Duplicate Transaction Reference"), false
+	}
+	return ref, response{}, true
+}
+
+// customerByEmail returns the customer with email, creating it if needed
+func (s *Server) customerByEmail(email string) object {
+	if cust := s.store.find("customer", email); cust != nil {
+		return cust
+	}
+	return s.store.create("customer", object{"email": email, "risk_action": "default"})
+}
+
+// newTransaction creates a transaction that has not been paid yet
+func (s *Server) newTransaction(cust object, ref string, amount int64, currency string) object {
+	return s.store.create("transaction", object{
+		"reference":        ref,
+		"amount":           amount,
+		"currency":         currency,
+		"status":           "abandoned",
+		"gateway_response": "The transaction was not completed",
+		"channel":          "card",
+		"ip_address":       "127.0.0.1",
+		"fees":             0,
+		"customer":         cust,
+		"log":              nil,
+	})
+}
+
+// succeed marks txn paid with auth, and credits the balance
+func (s *Server) succeed(txn, auth object) {
+	fees := transactionFees(txn["amount"].(int64))
+	txn["status"] = "success"
+	txn["gateway_response"] = "Successful"
+	txn["paid_at"] = now()
+	txn["fees"] = fees
+	txn["authorization"] = auth
+	s.store.touch("transaction", txn, false)
+	delete(s.store.pending, txn["reference"].(string))
+	if txn["currency"] == "NGN" {
+		s.store.balance += txn["amount"].(int64) - fees
+	}
+}
+
+// transactionFees returns Paystack's local fees: 1.5%, plus NGN 100 from
+// NGN 2,500, capped at NGN 2,000
+func transactionFees(amount int64) int64 {
+	fees := amount * 15 / 1000
+	if amount >= 250000 {
+		fees += 10000
+	}
+	return min(fees, 200000)
+}
+
+// newAuthorization saves a reusable card authorization of cust
+func (s *Server) newAuthorization(cust object, bin, last4 string) object {
+	auth := s.store.create("authorization", object{
+		"bin":          bin,
+		"last4":        last4,
+		"exp_month":    "12",
+		"exp_year":     "2030",
+		"channel":      "card",
+		"card_type":    "visa",
+		"bank":         "TEST BANK",
+		"country_code": "NG",
+		"brand":        "visa",
+		"reusable":     true,
+		"customer":     cust["id"],
+	})
+	auth["signature"] = code("SIG_", auth["id"].(int))
+	return auth
+}
+
+// Pay completes the transaction with reference, as if its customer had paid
+// on the checkout page with a test card. It saves a reusable authorization,
+// and subscribes the customer when the transaction is for a plan.
+func (s *Server) Pay(reference string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	txn := s.store.find("transaction", reference)
+	if txn == nil {
+		return errors.New("paystacktest: transaction not found")
+	}
+	if txn["status"] != "abandoned" {
+		return fmt.Errorf("paystacktest: transaction is %s", txn["status"])
+	}
+	cust := txn["customer"].(object)
+	auth := s.newAuthorization(cust, "408408", "4081")
+	s.succeed(txn, auth)
+	if plan, ok := txn["plan"].(object); ok {
+		s.subscribe(cust, plan, auth)
+	}
+	return nil
+}
+
+func (s *Server) verifyTransaction(r *request) response {
+	txn := s.store.find("transaction", r.param("reference"))
+	if txn == nil || txn["reference"] != r.param("reference") {
+		return notFound("Transaction reference")
+	}
+	return ok("Verification successful", txn)
+}
+
+func (s *Server) listTransactions(r *request) response {
+	txns := s.store.all("transaction", func(txn object) bool {
+		return matches(txn, "customer", r.query("customer")) &&
+			matches(txn, "status", r.query("status")) &&
+			matches(txn, "amount", r.query("amount")) &&
+			matches(txn, "currency", r.query("currency")) &&
+			matches(txn, "channel", r.query("channel"))
+	})
+	return paginate(r, "Transactions retrieved", txns)
+}
+
+func (s *Server) getTransaction(r *request) response {
+	txn := s.store.find("transaction", r.param("id"))
+	if txn == nil || fmt.Sprint(txn["id"]) != r.param("id") {
+		return notFound("Transaction")
+	}
+	return ok("Transaction retrieved", txn)
+}
+
+func (s *Server) chargeAuthorization(r *request) response {
+	auth := s.store.find("authorization", r.str("authorization_code"))
+	if auth == nil {
+		return invalid("Invalid authorization code")
+	}
+	cust := s.store.find("customer", r.str("email"))
+	if cust == nil || cust["id"] != auth["customer"] {
+		return invalid("Authorization does not belong to this customer")
+	}
+	amount := r.int("amount")
+	if amount <= 0 {
+		return invalid("Invalid Amount Sent")
+	}
+	ref, resp, fresh := s.newReference(r)
+	if !fresh {
+		return resp
+	}
+	txn := s.newTransaction(cust, ref, amount, currency(r))
+	txn["metadata"] = r.body["metadata"]
+	s.succeed(txn, auth)
+	return ok("Charge attempted", txn)
+}
+
+func (s *Server) requestReauthorization(r *request) response {
+	auth := s.store.find("authorization", r.str("authorization_code"))
+	if auth == nil {
+		return invalid("Invalid authorization code")
+	}
+	return ok("Reauthorization initiated", object{
+		"reauthorization_url": "https://checkout.paystack.com/reauthorize/" + auth["authorization_code"].(string),
+		"reference":           fmt.Sprintf("R%09d", s.store.nextID),
+	})
+}
+
+func (s *Server) checkReauthorization(r *request) response {
+	auth := s.store.find("authorization", r.str("authorization_code"))
+	if auth == nil {
+		return invalid("Invalid authorization code")
+	}
+	return ok("Authorization is valid for this amount", object{"amount": r.int("amount")})
+}
+
+func (s *Server) transactionTimeline(r *request) response {
+	txn := s.store.find("transaction", r.param("id"))
+	if txn == nil {
+		return notFound("Transaction")
+	}
+	success := txn["status"] == "success"
+	history := []object{{"type": "action", "message": "Attempted to pay", "time": 1}}
+	if success {
+		history = append(history, object{"type": "success", "message": "Successfully paid", "time": 2})
+	}
+	return ok("Timeline retrieved", object{
+		"time_spent":     2,
+		"attempts":       1,
+		"authentication": nil,
+		"errors":         0,
+		"success":        success,
+		"mobile":         false,
+		"input":          []string{},
+		"channel":        txn["channel"],
+		"history":        history,
+	})
+}
+
+func (s *Server) transactionTotals(r *request) response {
+	var total int64
+	count := 0
+	byCurrency := map[string]int64{}
+	customers := map[interface{}]bool{}
+	for _, txn := range filterDates(r, s.store.all("transaction", nil)) {
+		if txn["status"] != "success" {
+			continue
+		}
+		count++
+		total += txn["amount"].(int64)
+		byCurrency[txn["currency"].(string)] += txn["amount"].(int64)
+		customers[txn["customer"].(object)["id"]] = true
+	}
+	volumes := []object{}
+	for cur, amount := range byCurrency {
+		volumes = append(volumes, object{"currency": cur, "amount": amount})
+	}
+	return ok("Transaction totals", object{
+		"total_transactions":            count,
+		"unique_customers":              len(customers),
+		"total_volume":                  total,
+		"total_volume_by_currency":      volumes,
+		"pending_transfers":             0,
+		"pending_transfers_by_currency": []object{},
+	})
+}
+
+func (s *Server) exportTransactions(r *request) response {
+	return ok("Export successful", object{
+		"path": fmt.Sprintf("https://files.paystack.co/exports/%d/transactions.csv", integrationID),
+	})
+}
+
+// Charges
+
+func (s *Server) createCharge(r *request) response {
+	email := r.str("email")
+	if !strings.Contains(email, "@") {
+		return invalid("Invalid Email Address Passed")
+	}
+	amount := r.int("amount")
+	if amount <= 0 {
+		return invalid("Invalid Amount Sent")
+	}
+	ref, resp, fresh := s.newReference(r)
+	if !fresh {
+		return resp
+	}
+	cust := s.customerByEmail(email)
+
+	var auth object
+	flow := &chargeFlow{scenario: ScenarioSuccess}
+	switch {
+	case r.str("authorization_code") != "":
+		auth = s.store.find("authorization", r.str("authorization_code"))
+		if auth == nil || auth["customer"] != cust["id"] {
+			return invalid("Invalid authorization code")
+		}
+	case r.has("card"):
+		card, _ := r.body["card"].(map[string]interface{})
+		number := cardNumber(toString(card["card_number"]))
+		if len(number) < 12 {
+			return invalid("Invalid card number")
+		}
+		auth = s.newAuthorization(cust, number[:6], number[len(number)-4:])
+		flow.scenario = s.cardScenario(number)
+		if flow.awaiting() == "send_pin" && r.str("pin") != "" {
+			if !validInput("send_pin", r.str("pin")) {
+				return invalid("Invalid PIN")
+			}
+			flow.step++
+		}
+	case r.has("bank"):
+		auth = s.newAuthorization(cust, "", "")
+		auth["channel"], auth["reusable"] = "bank", false
+		// bank charges are confirmed with an OTP
+		flow.scenario = ScenarioSendOTP
+	default:
+		return invalid("Please provide a card, bank or authorization code")
+	}
+
+	txn := s.newTransaction(cust, ref, amount, currency(r))
+	txn["metadata"] = r.body["metadata"]
+	txn["channel"] = auth["channel"]
+	txn["authorization"] = auth
+	return s.playCharge(txn, flow)
+}
+
+var chargePrompts = map[string]string{
+	"send_pin":      "Please enter your PIN",
+	"send_otp":      "Please enter the OTP sent to your phone",
+	"send_phone":    "Please enter your phone number",
+	"send_birthday": "Please enter your birthday",
+}
+
+// chargeData is the data of a charge response about txn
+func (s *Server) chargeData(txn object) object {
+	status := txn["status"]
+	if flow, ok := s.store.pending[txn["reference"].(string)]; ok && flow.awaiting() != "" {
+		status = flow.awaiting()
+	}
+	return object{
+		"id":               txn["id"],
+		"amount":           txn["amount"],
+		"currency":         txn["currency"],
+		"transaction_date": txn["createdAt"],
+		"status":           status,
+		"reference":        txn["reference"],
+		"domain":           txn["domain"],
+		"metadata":         txn["metadata"],
+		"gateway_response": txn["gateway_response"],
+		"channel":          txn["channel"],
+		"fees":             txn["fees"],
+		"authorization":    txn["authorization"],
+		"customer":         txn["customer"],
+	}
+}
+
+// submitCharge returns a handler continuing a charge awaiting step with
+// the value in the body
+func (s *Server) submitCharge(step string) handler {
+	return func(r *request) response {
+		ref := r.str("reference")
+		txn := s.store.find("transaction", ref)
+		if txn == nil {
+			return notFound("Transaction reference")
+		}
+		flow := s.store.pending[ref]
+		if flow == nil || flow.awaiting() != step {
+			return invalid("Charge is not awaiting this input")
+		}
+		value := r.str(strings.TrimPrefix(step, "send_"))
+		if value == "" {
+			// the client sends every submission in the pin field
+			value = r.str("pin")
+		}
+		if !validInput(step, value) {
+			s.failCharge(txn, "Declined")
+			return ok("Charge attempted", s.chargeData(txn))
+		}
+		flow.step++
+		return s.playCharge(txn, flow)
+	}
+}
+
+// checkPendingCharge answers with the status of a charge. Charges that
+// timed out are failed when checked.
+func (s *Server) checkPendingCharge(r *request) response {
+	txn := s.store.find("transaction", r.param("reference"))
+	if txn == nil {
+		return notFound("Transaction reference")
+	}
+	flow := s.store.pending[txn["reference"].(string)]
+	if flow == nil || flow.awaiting() != "" {
+		return ok("Charge attempted", s.chargeData(txn))
+	}
+	s.failCharge(txn, flow.scenario.GatewayResponse)
+	data := s.chargeData(txn)
+	data["status"] = "timeout"
+	return ok("Charge attempted", data)
+}
+
+func (s *Server) tokenize(r *request) response {
+	card, _ := r.body["card"].(map[string]interface{})
+	number := cardNumber(toString(card["card_number"]))
+	if len(number) < 12 {
+		return invalid("Invalid card number")
+	}
+	cust := s.customerByEmail(r.str("email"))
+	auth := s.newAuthorization(cust, number[:6], number[len(number)-4:])
+	data := object{"customer": cust}
+	for k, v := range auth {
+		data[k] = v
+	}
+	return ok("Charge tokenized", data)
+}
+
+// Bulk charges
+
+func (s *Server) initiateBulkCharge(r *request) response {
+	items, _ := r.body["items"].([]interface{})
+	if len(items) == 0 {
+		return invalid("Please provide a list of charges")
+	}
+	batch := s.store.create("bulkcharge", object{
+		"status":          "active",
+		"total_charges":   len(items),
+		"pending_charges": 0,
+	})
+	batch["reference"] = fmt.Sprintf("bulkcharge-%d", batch["id"])
+	for _, item := range items {
+		m, _ := item.(map[string]interface{})
+		amount := int64(0)
+		if a, ok := m["amount"].(float64); ok {
+			amount = int64(a)
+		}
+		charge := object{
+			"bulkcharge": batch["id"],
+			"amount":     amount,
+			"currency":   "NGN",
+			"status":     "failed",
+		}
+		if auth := s.store.find("authorization", toString(m["authorization"])); auth != nil && amount > 0 {
+			cust := s.store.find("customer", fmt.Sprint(auth["customer"]))
+			txn := s.newTransaction(cust, fmt.Sprintf("T%09d", s.store.nextID), amount, "NGN")
+			s.succeed(txn, auth)
+			charge["status"] = "success"
+			charge["authorization"], charge["customer"], charge["transaction"] = auth, cust, txn
+		}
+		s.store.create("bulkitem", charge)
+	}
+	batch["status"] = "complete"
+	return ok("Charges have been queued", batch)
+}
+
+func (s *Server) listBulkCharges(r *request) response {
+	return paginate(r, "Bulk charges retrieved", s.store.all("bulkcharge", nil))
+}
+
+func (s *Server) getBulkCharge(r *request) response {
+	batch := s.store.find("bulkcharge", r.param("code"))
+	if batch == nil {
+		return notFound("Bulk charge batch")
+	}
+	return ok("Bulk charge retrieved", batch)
+}
+
+func (s *Server) getBulkChargeCharges(r *request) response {
+	batch := s.store.find("bulkcharge", r.param("code"))
+	if batch == nil {
+		return notFound("Bulk charge batch")
+	}
+	charges := s.store.all("bulkitem", func(item object) bool {
+		return item["bulkcharge"] == batch["id"] && matches(item, "status", r.query("status"))
+	})
+	return paginate(r, "Bulk charge items retrieved", charges)
+}
+
+// setBulkChargeStatus returns a handler pausing or resuming a batch
+func (s *Server) setBulkChargeStatus(status string) handler {
+	return func(r *request) response {
+		batch := s.store.find("bulkcharge", r.param("code"))
+		if batch == nil {
+			return notFound("Bulk charge batch")
+		}
+		if batch["status"] != "complete" {
+			batch["status"] = status
+			s.store.touch("bulkcharge", batch, false)
+		}
+		if status == "paused" {
+			return ok("Bulk charge batch has been paused", nil)
+		}
+		return ok("Bulk charge batch has been resumed", nil)
+	}
+}
+
+// Refunds
+
+func (s *Server) createRefund(r *request) response {
+	txn := s.store.find("transaction", r.str("transaction"))
+	if txn == nil {
+		return notFound("Transaction")
+	}
+	if txn["status"] != "success" {
+		return invalid("Cannot refund a transaction that was not successful")
+	}
+	id := txn["id"].(int)
+	remaining := txn["amount"].(int64) - s.store.refunded[id]
+	if remaining <= 0 {
+		return invalid("Transaction has been fully reversed")
+	}
+	amount := r.int("amount")
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount < 0 || amount > remaining {
+		return invalid("Refund amount cannot be greater than transaction amount")
+	}
+	s.store.refunded[id] += amount
+	if s.store.refunded[id] == txn["amount"].(int64) {
+		txn["status"] = "reversed"
+		s.store.touch("transaction", txn, false)
+	}
+	refund := s.store.create("refund", object{
+		"transaction":     txn,
+		"amount":          amount,
+		"deducted_amount": 0,
+		"currency":        txn["currency"],
+		"channel":         nil,
+		"merchant_note":   r.str("merchant_note"),
+		"customer_note":   r.str("customer_note"),
+		"status":          "pending",
+		"refunded_by":     "test@paystack.com",
+		"fully_deducted":  false,
+		"expected_at":     time.Now().UTC().Add(7 * 24 * time.Hour).Format(timeLayout),
+	})
+	return ok("Refund has been queued for processing", refund)
+}
+
+func (s *Server) listRefunds(r *request) response {
+	var txnID string
+	if q := r.query("transaction"); q != "" {
+		txn := s.store.find("transaction", q)
+		if txn == nil {
+			return paginate(r, "Refunds retrieved", nil)
+		}
+		txnID = fmt.Sprint(txn["id"])
+	}
+	refunds := s.store.all("refund", func(refund object) bool {
+		return matches(refund, "transaction", txnID) &&
+			matches(refund, "currency", r.query("currency"))
+	})
+	return paginate(r, "Refunds retrieved", refunds)
+}
+
+func (s *Server) getRefund(r *request) response {
+	refund := s.store.find("refund", r.param("id"))
+	if refund == nil {
+		return notFound("Refund")
+	}
+	return ok("Refund retrieved", refund)
+}
+
+// Disputes
+
+// OpenDispute opens a chargeback dispute on the successful transaction
+// with reference, as if the customer had raised one with their bank, and
+// returns its ID.
+func (s *Server) OpenDispute(reference string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	txn := s.store.find("transaction", reference)
+	if txn == nil {
+		return 0, errors.New("paystacktest: transaction not found")
+	}
+	if txn["status"] != "success" {
+		return 0, fmt.Errorf("paystacktest: transaction is %s", txn["status"])
+	}
+	auth, _ := txn["authorization"].(object)
+	dispute := s.store.create("dispute", object{
+		"transaction":                    txn,
+		"transaction_reference":          txn["reference"],
+		"merchant_transaction_reference": txn["reference"],
+		"currency":                       txn["currency"],
+		"refund_amount":                  txn["amount"],
+		"bin":                            auth["bin"],
+		"last4":                          auth["last4"],
+		"status":                         "awaiting-merchant-feedback",
+		"category":                       "chargeback",
+		"resolution":                     nil,
+		"note":                           nil,
+		"attachments":                    nil,
+		"messages":                       []object{},
+		"dueAt":                          time.Now().UTC().Add(72 * time.Hour).Format(timeLayout),
+	})
+	dispute["history"] = []object{{
+		"dispute":   dispute["id"],
+		"status":    "awaiting-merchant-feedback",
+		"by":        "customer",
+		"createdAt": dispute["createdAt"],
+	}}
+	return dispute["id"].(int), nil
+}
+
+func (s *Server) listDisputes(r *request) response {
+	disputes := s.store.all("dispute", func(d object) bool {
+		return matches(d, "transaction", r.query("transaction")) &&
+			matches(d, "status", r.query("status"))
+	})
+	return paginate(r, "Disputes retrieved", disputes)
+}
+
+func (s *Server) getDispute(r *request) response {
+	dispute := s.store.find("dispute", r.param("id"))
+	if dispute == nil {
+		return notFound("Dispute")
+	}
+	return ok("Dispute retrieved", dispute)
+}
+
+func (s *Server) getTransactionDispute(r *request) response {
+	disputes := s.store.all("dispute", func(d object) bool {
+		return matches(d, "transaction", r.param("id"))
+	})
+	if len(disputes) == 0 {
+		return notFound("Dispute")
+	}
+	return ok("Dispute retrieved", disputes[0])
+}
+
+func (s *Server) updateDispute(r *request) response {
+	dispute := s.store.find("dispute", r.param("id"))
+	if dispute == nil {
+		return notFound("Dispute")
+	}
+	if dispute["status"] == "resolved" {
+		return invalid("Dispute has already been resolved")
+	}
+	if r.has("refund_amount") {
+		amount := r.int("refund_amount")
+		if amount < 0 || amount > dispute["transaction"].(object)["amount"].(int64) {
+			return invalid("Refund amount cannot be greater than transaction amount")
+		}
+		dispute["refund_amount"] = amount
+	}
+	copyFields(r, dispute, "uploaded_filename")
+	s.store.touch("dispute", dispute, false)
+	return ok("Dispute updated successfully", dispute)
+}
+
+func (s *Server) addDisputeEvidence(r *request) response {
+	dispute := s.store.find("dispute", r.param("id"))
+	if dispute == nil {
+		return notFound("Dispute")
+	}
+	for _, k := range []string{"customer_email", "customer_name", "customer_phone", "service_details"} {
+		if r.str(k) == "" {
+			return invalid(k + " is required")
+		}
+	}
+	evidence := object{"dispute": dispute["id"]}
+	copyFields(r, evidence, "customer_email", "customer_name", "customer_phone",
+		"service_details", "delivery_address", "delivery_date")
+	evidence = s.store.create("evidence", evidence)
+	dispute["evidence"] = evidence
+	s.store.touch("dispute", dispute, false)
+	return created("Evidence created", evidence)
+}
+
+func (s *Server) resolveDispute(r *request) response {
+	dispute := s.store.find("dispute", r.param("id"))
+	if dispute == nil {
+		return notFound("Dispute")
+	}
+	if dispute["status"] == "resolved" {
+		return invalid("Dispute has already been resolved")
+	}
+	resolution := r.str("resolution")
+	switch {
+	case resolution != "merchant-accepted" && resolution != "declined":
+		return invalid("Resolution must be merchant-accepted or declined")
+	case r.str("message") == "":
+		return invalid("Message is required")
+	case r.str("uploaded_filename") == "":
+		return invalid("Uploaded filename is required")
+	case resolution == "declined" && s.store.find("evidence", r.str("evidence")) == nil:
+		return invalid("Evidence is required to decline a dispute")
+	}
+	dispute["status"] = "resolved"
+	dispute["resolution"] = resolution
+	dispute["resolvedAt"] = now()
+	if r.has("refund_amount") {
+		dispute["refund_amount"] = r.int("refund_amount")
+	}
+	dispute["history"] = append(dispute["history"].([]object), object{
+		"dispute":   dispute["id"],
+		"status":    "resolved",
+		"by":        "merchant",
+		"createdAt": dispute["resolvedAt"],
+	})
+	dispute["messages"] = append(dispute["messages"].([]object), object{
+		"dispute":   dispute["id"],
+		"sender":    "merchant",
+		"body":      r.str("message"),
+		"createdAt": dispute["resolvedAt"],
+	})
+	s.store.touch("dispute", dispute, false)
+	return ok("Dispute successfully resolved", dispute)
+}
+
+func (s *Server) disputeUploadURL(r *request) response {
+	dispute := s.store.find("dispute", r.param("id"))
+	if dispute == nil {
+		return notFound("Dispute")
+	}
+	name := r.query("upload_filename")
+	if name == "" {
+		return invalid("Upload filename is required")
+	}
+	return ok("Upload url generated", object{
+		"signedUrl": fmt.Sprintf("https://files.paystack.co/disputes/%d/%s?signature=test", dispute["id"], name),
+		"fileName":  name,
+	})
+}
+
+func (s *Server) exportDisputes(r *request) response {
+	return ok("Export successful", object{
+		"path":      fmt.Sprintf("https://files.paystack.co/exports/%d/disputes.csv", integrationID),
+		"expiresAt": time.Now().UTC().Add(time.Hour).Format(timeLayout),
+	})
+}
@@ -0,0 +1,132 @@
+package paystacktest
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func recordedRequestTo(t *testing.T, rec *Recorder, method, path, body string) (int, string, error) {
+	t.Helper()
+	req, err := http.NewRequest(method, "https://api.paystack.co"+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+SecretKey)
+	resp, err := rec.Client().Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data), nil
+}
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassettes", "charge.json")
+
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Transport = srv.Client().Transport
+	rec.IgnoreFields = []string{"reference"}
+	rec.Source = "paystacktest"
+	status, recorded, err := recordedRequestTo(t, rec, "POST", "/charge",
+		`{"email":"rec@example.com","amount":10000,"reference":"run-1","card":{"card_number":"4084084084084081","card_cvc":"408"}}`)
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("got %d %v", status, err)
+	}
+	if _, _, err := recordedRequestTo(t, rec, "GET", "/bank?perPage=5&page=1", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := recordedRequestTo(t, rec, "GET", "/bank/resolve_bvn/21212917741", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{SecretKey, "Authorization", "4084084084084081", `"408"`, "21212917741"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette holds %q", secret)
+		}
+	}
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Source != "paystacktest" {
+		t.Errorf("got source %q, want the one recorded", rec.Source)
+	}
+	rec.IgnoreFields = []string{"reference"}
+	// the key order of the body, the reference and the query order differ
+	status, replayed, err := recordedRequestTo(t, rec, "POST", "/charge",
+		`{"card":{"card_cvc":"123","card_number":"5060666666666666666"},"reference":"run-2","amount":10000,"email":"rec@example.com"}`)
+	if err != nil || status != http.StatusOK || replayed != recorded {
+		t.Errorf("got %d %v %s, want the recorded response %s", status, err, replayed, recorded)
+	}
+	if _, _, err := recordedRequestTo(t, rec, "GET", "/bank?page=1&perPage=5", ""); err != nil {
+		t.Error(err)
+	}
+	if _, _, err := recordedRequestTo(t, rec, "GET", "/bank/resolve_bvn/12345678901", ""); err != nil {
+		t.Error(err)
+	}
+	if _, _, err := recordedRequestTo(t, rec, "POST", "/charge", `{"email":"other@example.com","amount":10000}`); err == nil {
+		t.Error("expected an error for a request that was not recorded")
+	}
+	if n := srv.Calls("", "/charge"); n != 1 {
+		t.Errorf("got %d calls to the server, want only the recorded one", n)
+	}
+}
+
+func TestRecorderReplaysInOrder(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Transport = srv.Client().Transport
+	var recorded []string
+	for _, timeout := range []string{"10", "20"} {
+		recordedRequestTo(t, rec, "PUT", "/integration/payment_session_timeout", `{"timeout":`+timeout+`}`)
+		_, body, _ := recordedRequestTo(t, rec, "GET", "/integration/payment_session_timeout", "")
+		recorded = append(recorded, body)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range append(recorded, recorded[1]) {
+		_, got, err := recordedRequestTo(t, rec, "GET", "/integration/payment_session_timeout", "")
+		if err != nil || got != want {
+			t.Errorf("replay %d: got %s %v, want %s", i, got, err, want)
+		}
+	}
+}
+
+func TestNewRecorderMissingCassette(t *testing.T) {
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("expected an error for a missing cassette")
+	}
+}
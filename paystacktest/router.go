@@ -0,0 +1,139 @@
+package paystacktest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// handler answers a request. Handlers run with the server's lock held.
+type handler func(r *request) response
+
+// route maps a method and a path pattern such as /customer/{code} to a handler
+type route struct {
+	method   string
+	segments []string
+	h        handler
+}
+
+// match returns the handler of the first route matching method and path,
+// with the values of its {param} segments. allowed reports whether a route
+// matched path with another method.
+func (s *Server) match(method, path string) (h handler, params map[string]string, allowed bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, rt := range s.routes {
+		p, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if rt.method != method {
+			allowed = true
+			continue
+		}
+		return rt.h, p, false
+	}
+	return nil, nil, allowed
+}
+
+func (rt route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+	var params map[string]string
+	for i, seg := range rt.segments {
+		if strings.HasPrefix(seg, "{") {
+			if params == nil {
+				params = make(map[string]string)
+			}
+			// some client paths put a colon before IDs, as in the API docs
+			params[strings.Trim(seg, "{}")] = strings.TrimPrefix(segments[i], ":")
+			continue
+		}
+		if seg != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// request is an API request with its JSON body decoded
+type request struct {
+	*http.Request
+	params map[string]string
+	body   map[string]interface{}
+}
+
+func newRequest(r *http.Request) (*request, error) {
+	req := &request{Request: r, body: map[string]interface{}{}}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return req, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		req.body = v
+	case []interface{}:
+		// bulk endpoints take a list
+		req.body = map[string]interface{}{"items": v}
+	}
+	return req, nil
+}
+
+// param returns the path parameter name
+func (r *request) param(name string) string {
+	return r.params[name]
+}
+
+// str returns the body field key as a string. Numbers are formatted, and
+// a list holding one value, as sent for url.Values, is unwrapped.
+func (r *request) str(key string) string {
+	return toString(r.body[key])
+}
+
+// has reports whether the body has the field key
+func (r *request) has(key string) bool {
+	_, ok := r.body[key]
+	return ok
+}
+
+// int returns the body field key as an integer, or 0
+func (r *request) int(key string) int64 {
+	n, _ := strconv.ParseInt(r.str(key), 10, 64)
+	return n
+}
+
+// bool returns the body field key as a boolean
+func (r *request) bool(key string) bool {
+	b, _ := strconv.ParseBool(r.str(key))
+	return b
+}
+
+// query returns the query parameter key
+func (r *request) query(key string) string {
+	return r.URL.Query().Get(key)
+}
+
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		if len(v) == 1 {
+			return toString(v[0])
+		}
+	}
+	return ""
+}
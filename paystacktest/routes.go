@@ -0,0 +1,130 @@
+package paystacktest
+
+import "strings"
+
+// routeTable lists the endpoints of the server. Routes are matched in
+// order, so static paths come before parameterised ones of the same length.
+func (s *Server) routeTable() []route {
+	return []route{
+		get("/bank", s.listBanks),
+		get("/bank/resolve", s.resolveAccountNumber),
+		get("/bank/resolve_bvn/{bvn}", s.resolveBVN),
+		get("/decision/bin/{bin}", s.resolveCardBIN),
+		get("/balance", s.checkBalance),
+		get("/integration/payment_session_timeout", s.getSessionTimeout),
+		put("/integration/payment_session_timeout", s.updateSessionTimeout),
+		get("/settlement", s.listSettlements),
+
+		post("/customer", s.createCustomer),
+		get("/customer", s.listCustomers),
+		post("/customer/set_risk_action", s.setRiskAction),
+		post("/customer/deactivate_authorization", s.deactivateAuthorization),
+		get("/customer/{code}", s.getCustomer),
+		put("/customer/{code}", s.updateCustomer),
+
+		post("/transaction/initialize", s.initializeTransaction),
+		post("/transaction/charge_authorization", s.chargeAuthorization),
+		post("/transaction/request_reauthorization", s.requestReauthorization),
+		post("/transaction/check_reauthorization", s.checkReauthorization),
+		get("/transaction/totals", s.transactionTotals),
+		get("/transaction/export", s.exportTransactions),
+		get("/transaction", s.listTransactions),
+		get("/transaction/verify/{reference}", s.verifyTransaction),
+		get("/transaction/timeline/{id}", s.transactionTimeline),
+		get("/transaction/{id}", s.getTransaction),
+
+		post("/charge", s.createCharge),
+		post("/charge/tokenize", s.tokenize),
+		post("/charge/submit_pin", s.submitCharge("send_pin")),
+		post("/charge/submit_otp", s.submitCharge("send_otp")),
+		post("/charge/submit_phone", s.submitCharge("send_phone")),
+		post("/charge/submit_birthday", s.submitCharge("send_birthday")),
+		get("/charge/{reference}", s.checkPendingCharge),
+
+		post("/bulkcharge", s.initiateBulkCharge),
+		get("/bulkcharge", s.listBulkCharges),
+		get("/bulkcharge/pause/{code}", s.setBulkChargeStatus("paused")),
+		get("/bulkcharge/resume/{code}", s.setBulkChargeStatus("active")),
+		get("/bulkcharge/{code}", s.getBulkCharge),
+		get("/bulkcharge/{code}/charges", s.getBulkChargeCharges),
+
+		post("/refund", s.createRefund),
+		get("/refund", s.listRefunds),
+		get("/refund/{id}", s.getRefund),
+
+		get("/dispute", s.listDisputes),
+		get("/dispute/export", s.exportDisputes),
+		get("/dispute/transaction/{id}", s.getTransactionDispute),
+		get("/dispute/{id}", s.getDispute),
+		put("/dispute/{id}", s.updateDispute),
+		post("/dispute/{id}/evidence", s.addDisputeEvidence),
+		put("/dispute/{id}/resolve", s.resolveDispute),
+		get("/dispute/{id}/upload_url", s.disputeUploadURL),
+
+		post("/plan", s.createPlan),
+		get("/plan", s.listPlans),
+		get("/plan/{code}", s.getPlan),
+		put("/plan/{code}", s.updatePlan),
+
+		post("/subscription", s.createSubscription),
+		get("/subscription", s.listSubscriptions),
+		post("/subscription/enable", s.setSubscriptionStatus("active")),
+		post("/subscription/disable", s.setSubscriptionStatus("cancelled")),
+		get("/subscription/{code}", s.getSubscription),
+		put("/subscription/{code}", s.updateSubscription),
+
+		post("/page", s.createPage),
+		get("/page", s.listPages),
+		get("/page/{slug}", s.getPage),
+		put("/page/{slug}", s.updatePage),
+
+		post("/product", s.createProduct),
+		get("/product", s.listProducts),
+		get("/product/{id}", s.getProduct),
+		put("/product/{id}", s.updateProduct),
+
+		post("/subaccount", s.createSubAccount),
+		get("/subaccount", s.listSubAccounts),
+		get("/subaccount/{code}", s.getSubAccount),
+		put("/subaccount/{code}", s.updateSubAccount),
+
+		post("/split", s.createSplit),
+		get("/split", s.listSplits),
+		get("/split/{id}", s.getSplit),
+		put("/split/{id}", s.updateSplit),
+		post("/split/{id}/subaccount/add", s.addSplitSubAccount),
+		post("/split/{id}/subaccount/remove", s.removeSplitSubAccount),
+
+		post("/transferrecipient", s.createRecipient),
+		get("/transferrecipient", s.listRecipients),
+
+		post("/transfer", s.initiateTransfer),
+		get("/transfer", s.listTransfers),
+		post("/transfer/finalize_transfer", s.finalizeTransfer),
+		post("/transfer/resend_otp", s.resendTransferOTP),
+		post("/transfer/enable_otp", s.enableTransferOTP),
+		post("/transfer/disable_otp", s.disableTransferOTP),
+		post("/transfer/disable_otp_finalize", s.finalizeDisableTransferOTP),
+		get("/transfer/verify/{reference}", s.verifyTransfer),
+		get("/transfer/{code}", s.getTransfer),
+
+		post("/dedicated_account", s.createDVA),
+		get("/dedicated_account", s.listDVAs),
+		post("/dedicated_account/assign", s.assignDVA),
+		post("/dedicated_account/split", s.splitDVA),
+		del("/dedicated_account/split", s.removeDVASplit),
+		get("/dedicated_account/requery", s.requeryDVA),
+		get("/dedicated_account/available_providers", s.listDVAProviders),
+		get("/dedicated_account/{id}", s.getDVA),
+		del("/dedicated_account/{id}", s.deactivateDVA),
+	}
+}
+
+func newRoute(method, pattern string, h handler) route {
+	return route{method: method, segments: strings.Split(strings.Trim(pattern, "/"), "/"), h: h}
+}
+
+func get(pattern string, h handler) route  { return newRoute("GET", pattern, h) }
+func post(pattern string, h handler) route { return newRoute("POST", pattern, h) }
+func put(pattern string, h handler) route  { return newRoute("PUT", pattern, h) }
+func del(pattern string, h handler) route  { return newRoute("DELETE", pattern, h) }
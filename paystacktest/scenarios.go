@@ -0,0 +1,144 @@
+package paystacktest
+
+import (
+	"strings"
+	"time"
+)
+
+// Scenario is how a charge plays out: the inputs it asks for in turn and
+// how it ends once they are submitted.
+type Scenario struct {
+	// Name identifies the scenario, e.g. "send_otp".
+	Name string
+	// Steps are the inputs the charge awaits in order, each one of
+	// "send_pin", "send_otp", "send_phone" and "send_birthday".
+	Steps []string
+	// Status is the status the charge ends with: "success", "failed" or
+	// "timeout". A charge that times out stays pending until it is
+	// checked with GET /charge/{reference}.
+	Status string
+	// GatewayResponse is the message of the issuer the charge ends with.
+	GatewayResponse string
+}
+
+// Scenarios of card charges.
+var (
+	ScenarioSuccess           = Scenario{Name: "success", Status: "success", GatewayResponse: "Successful"}
+	ScenarioSendPIN           = Scenario{Name: "send_pin", Steps: []string{"send_pin"}, Status: "success", GatewayResponse: "Successful"}
+	ScenarioSendOTP           = Scenario{Name: "send_otp", Steps: []string{"send_otp"}, Status: "success", GatewayResponse: "Approved"}
+	ScenarioSendPINAndOTP     = Scenario{Name: "send_pin_otp", Steps: []string{"send_pin", "send_otp"}, Status: "success", GatewayResponse: "Approved"}
+	ScenarioSendPhone         = Scenario{Name: "send_phone", Steps: []string{"send_phone", "send_otp"}, Status: "success", GatewayResponse: "Approved"}
+	ScenarioSendBirthday      = Scenario{Name: "send_birthday", Steps: []string{"send_birthday"}, Status: "success", GatewayResponse: "Approved"}
+	ScenarioDeclined          = Scenario{Name: "declined", Status: "failed", GatewayResponse: "Declined"}
+	ScenarioInsufficientFunds = Scenario{Name: "insufficient_funds", Status: "failed", GatewayResponse: "Insufficient Funds"}
+	ScenarioTimeout           = Scenario{Name: "timeout", Status: "timeout", GatewayResponse: "Transaction timed out"}
+)
+
+// Test card numbers and the scenarios charging them plays out. Cards not
+// listed here, or set with SetCardScenario, are charged successfully.
+const (
+	CardSuccess           = "4084084084084081"
+	CardSendPIN           = "507850785078507812"
+	CardSendOTP           = "4084080000000409"
+	CardSendPINAndOTP     = "5060666666666666666"
+	CardSendPhone         = "5078507850785078016"
+	CardSendBirthday      = "4084080000000508"
+	CardDeclined          = "4084080000005408"
+	CardInsufficientFunds = "5060660000000000004"
+	CardTimeout           = "4084080000000607"
+)
+
+func defaultCards() map[string]Scenario {
+	return map[string]Scenario{
+		CardSuccess:           ScenarioSuccess,
+		CardSendPIN:           ScenarioSendPIN,
+		CardSendOTP:           ScenarioSendOTP,
+		CardSendPINAndOTP:     ScenarioSendPINAndOTP,
+		CardSendPhone:         ScenarioSendPhone,
+		CardSendBirthday:      ScenarioSendBirthday,
+		CardDeclined:          ScenarioDeclined,
+		CardInsufficientFunds: ScenarioInsufficientFunds,
+		CardTimeout:           ScenarioTimeout,
+	}
+}
+
+// SetCardScenario makes charges of the card number play out sc, replacing
+// the scenario of a test card.
+func (s *Server) SetCardScenario(number string, sc Scenario) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cards[cardNumber(number)] = sc
+}
+
+// cardScenario returns the scenario of the card number
+func (s *Server) cardScenario(number string) Scenario {
+	if sc, ok := s.cards[number]; ok {
+		return sc
+	}
+	return ScenarioSuccess
+}
+
+func cardNumber(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
+
+// chargeFlow is a charge awaiting input
+type chargeFlow struct {
+	scenario Scenario
+	step     int // index of the awaited step, len(Steps) once timed out
+}
+
+func (f *chargeFlow) awaiting() string {
+	if f.step < len(f.scenario.Steps) {
+		return f.scenario.Steps[f.step]
+	}
+	return ""
+}
+
+// playCharge advances the charge txn through flow: it awaits the next
+// step, or ends the charge as the scenario does.
+func (s *Server) playCharge(txn object, flow *chargeFlow) response {
+	ref := txn["reference"].(string)
+	if step := flow.awaiting(); step != "" {
+		s.store.pending[ref] = flow
+		txn["status"], txn["gateway_response"] = "pending", ""
+		data := s.chargeData(txn)
+		data["display_text"] = chargePrompts[step]
+		return ok("Charge attempted", data)
+	}
+
+	sc := flow.scenario
+	switch sc.Status {
+	case "success":
+		s.succeed(txn, txn["authorization"].(object))
+		txn["gateway_response"] = sc.GatewayResponse
+	case "timeout":
+		// the charge hangs until checked
+		s.store.pending[ref] = flow
+		txn["status"], txn["gateway_response"] = "pending", ""
+	default:
+		s.failCharge(txn, sc.GatewayResponse)
+	}
+	return ok("Charge attempted", s.chargeData(txn))
+}
+
+// failCharge ends the charge txn unsuccessfully
+func (s *Server) failCharge(txn object, gatewayResponse string) {
+	delete(s.store.pending, txn["reference"].(string))
+	txn["status"], txn["gateway_response"] = "failed", gatewayResponse
+	s.store.touch("transaction", txn, false)
+}
+
+// validInput reports whether value is acceptable input for step
+func validInput(step, value string) bool {
+	switch step {
+	case "send_pin":
+		return len(value) == 4 && strings.Trim(value, "0123456789") == ""
+	case "send_otp":
+		return value == TestOTP
+	case "send_birthday":
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	}
+	return value != ""
+}
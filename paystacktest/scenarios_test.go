@@ -0,0 +1,75 @@
+package paystacktest
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCardScenarios(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	charge := func(number, extra string) map[string]interface{} {
+		t.Helper()
+		status, env := do(t, srv, SecretKey, "POST", "/charge",
+			`{"email":"card@example.com","amount":10000,"card":{"card_number":"`+number+`"}`+extra+`}`)
+		if status != http.StatusOK {
+			t.Fatalf("charging %s: got %d %v", number, status, env)
+		}
+		return env["data"].(map[string]interface{})
+	}
+	submit := func(step, ref, value string) map[string]interface{} {
+		t.Helper()
+		status, env := do(t, srv, SecretKey, "POST", "/charge/submit_"+step,
+			`{"reference":"`+ref+`","pin":"`+value+`"}`)
+		if status != http.StatusOK {
+			t.Fatalf("submitting %s: got %d %v", step, status, env)
+		}
+		return env["data"].(map[string]interface{})
+	}
+
+	if data := charge(CardSuccess, ""); data["status"] != "success" {
+		t.Errorf("success card: got %v", data)
+	}
+
+	data := charge(CardSendPINAndOTP, "")
+	if data["status"] != "send_pin" {
+		t.Fatalf("got %v, want send_pin", data)
+	}
+	ref := data["reference"].(string)
+	if data := submit("pin", ref, "1234"); data["status"] != "send_otp" {
+		t.Fatalf("got %v, want send_otp", data)
+	}
+	if data := submit("otp", ref, TestOTP); data["status"] != "success" {
+		t.Errorf("got %v, want success", data)
+	}
+
+	// a PIN in the charge request skips the PIN step
+	if data := charge(CardSendPIN, `,"pin":"1111"`); data["status"] != "success" {
+		t.Errorf("got %v, want success", data)
+	}
+
+	data = charge(CardSendOTP, "")
+	if data := submit("otp", data["reference"].(string), "000000"); data["status"] != "failed" {
+		t.Errorf("wrong OTP: got %v, want failed", data)
+	}
+
+	data = charge(CardInsufficientFunds, "")
+	if data["status"] != "failed" || data["gateway_response"] != "Insufficient Funds" {
+		t.Errorf("got %v, want failed for insufficient funds", data)
+	}
+
+	data = charge(CardTimeout, "")
+	if data["status"] != "pending" {
+		t.Fatalf("got %v, want pending", data)
+	}
+	_, env := do(t, srv, SecretKey, "GET", "/charge/"+data["reference"].(string), "")
+	if got := env["data"].(map[string]interface{})["status"]; got != "timeout" {
+		t.Errorf("got %v, want timeout", got)
+	}
+
+	srv.SetCardScenario("4111 1111 1111 1111", ScenarioDeclined)
+	if data := charge("4111111111111111", ""); data["status"] != "failed" || data["gateway_response"] != "Declined" {
+		t.Errorf("got %v, want declined", data)
+	}
+}
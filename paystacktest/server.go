@@ -0,0 +1,224 @@
+// Package paystacktest provides an in-process fake of the Paystack API for
+// tests that should run without a key or network access.
+//
+// The fake keeps customers, transactions, transfers and the other resources
+// wrapped by package paystack in memory, and answers with the envelopes and
+// errors Paystack uses:
+//
+//	srv := paystacktest.NewServer()
+//	defer srv.Close()
+//	c := paystack.NewClient(paystacktest.SecretKey, srv.Client())
+//
+// Events that Paystack triggers outside the API, such as a customer paying
+// on the checkout page or opening a dispute, are simulated with methods of
+// Server like Pay and OpenDispute.
+package paystacktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// SecretKey is a test secret key accepted by the server. Any key starting
+// with "sk_test_" is accepted.
+const SecretKey = "sk_test_paystacktest"
+
+// DefaultBalance is the NGN balance, in kobo, of the integration of a new
+// server. Transfers are debited from it.
+const DefaultBalance = 100000000
+
+// Server is a fake Paystack API server. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, e.g. http://127.0.0.1:4242
+	URL string
+
+	srv    *httptest.Server
+	routes []route
+
+	mu    sync.Mutex
+	store *store
+	cards map[string]Scenario
+
+	faultMu sync.Mutex
+	faults  []*faultScript
+	calls   []call
+}
+
+// NewServer starts and returns a new fake Paystack server.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{store: newStore(), cards: defaultCards()}
+	s.routes = s.routeTable()
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server and blocks until all outstanding requests
+// on it have completed.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns an HTTP client that sends requests for any host, including
+// api.paystack.co, to the server.
+func (s *Server) Client() *http.Client {
+	u, _ := url.Parse(s.srv.URL)
+	return &http.Client{Transport: &rewriteTransport{host: u.Host, base: s.srv.Client().Transport}}
+}
+
+// rewriteTransport sends every request to host over plain HTTP
+type rewriteTransport struct {
+	host string
+	base http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = "http"
+	r.URL.Host = t.host
+	r.Host = t.host
+	return t.base.RoundTrip(r)
+}
+
+// response is what a handler answers with
+type response struct {
+	status  int
+	message string
+	data    interface{}
+	meta    interface{}
+}
+
+func ok(message string, data interface{}) response {
+	return response{status: http.StatusOK, message: message, data: data}
+}
+
+func created(message string, data interface{}) response {
+	return response{status: http.StatusCreated, message: message, data: data}
+}
+
+func fail(status int, message string) response {
+	return response{status: status, message: message}
+}
+
+func notFound(resource string) response {
+	return fail(http.StatusNotFound, resource+" not found")
+}
+
+func invalid(message string) response {
+	return fail(http.StatusBadRequest, message)
+}
+
+// envelope is the JSON object wrapping every Paystack response
+type envelope struct {
+	Status  bool        `json:"status"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+	Meta    interface{} `json:"meta,omitempty"`
+	Type    string      `json:"type,omitempty"`
+	Code    string      `json:"code,omitempty"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if f, ok := s.nextFault(r); ok && serveFault(w, r, f) {
+		return
+	}
+	resp := s.handle(r)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(resp.status)
+	writeEnvelope(w, resp)
+}
+
+// writeEnvelope writes resp wrapped in an envelope
+func writeEnvelope(w http.ResponseWriter, resp response) {
+	env := envelope{
+		Status:  resp.status < 400,
+		Message: resp.message,
+		Data:    resp.data,
+		Meta:    resp.meta,
+	}
+	if !env.Status {
+		env.Type, env.Code = errorType(resp.status), errorCode(resp)
+	}
+	json.NewEncoder(w).Encode(env)
+}
+
+func (s *Server) handle(r *http.Request) response {
+	if resp, ok := authorize(r); !ok {
+		return resp
+	}
+	req, err := newRequest(r)
+	if err != nil {
+		return invalid("Invalid JSON body: " + err.Error())
+	}
+	h, params, allowed := s.match(r.Method, r.URL.Path)
+	if h == nil {
+		if allowed {
+			return fail(http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return fail(http.StatusNotFound, "Route not found")
+	}
+	req.params = params
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := h(req)
+	// encode while locked, as data may share maps with the store
+	resp.data, resp.meta = encode(resp.data), encode(resp.meta)
+	return resp
+}
+
+func encode(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic("paystacktest: encoding response: " + err.Error())
+	}
+	return json.RawMessage(b)
+}
+
+// authorize checks the secret key in the Authorization header
+func authorize(r *http.Request) (response, bool) {
+	auth := r.Header.Get("Authorization")
+	if auth == "" {
+		return fail(http.StatusUnauthorized, "No Authorization Header was found"), false
+	}
+	key := strings.TrimPrefix(auth, "Bearer ")
+	if key == auth || !strings.HasPrefix(key, "sk_test_") {
+		return fail(http.StatusUnauthorized, "Invalid key"), false
+	}
+	return response{}, true
+}
+
+func errorType(status int) string {
+	switch {
+	case status == http.StatusUnauthorized:
+		return "authentication_error"
+	case status == http.StatusNotFound:
+		return "not_found_error"
+	case status >= 500:
+		return "api_error"
+	}
+	return "validation_error"
+}
+
+func errorCode(resp response) string {
+	msg := strings.ToLower(resp.message)
+	switch {
+	case strings.Contains(msg, "duplicate") && strings.Contains(msg, "reference"):
+		return "duplicate_reference"
+	case strings.Contains(msg, "balance is not enough"):
+		return "insufficient_balance"
+	case resp.status == http.StatusNotFound:
+		return "not_found"
+	case resp.status == http.StatusUnauthorized:
+		return "invalid_key"
+	}
+	return ""
+}
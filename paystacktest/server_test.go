@@ -0,0 +1,160 @@
+package paystacktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// do sends a request for path on api.paystack.co through srv.Client, and
+// decodes the envelope of the response.
+func do(t *testing.T, srv *Server, key, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(method, "https://api.paystack.co"+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var env map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, env
+}
+
+func TestServerRequiresSecretKey(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	for key, want := range map[string]string{
+		"":                 "No Authorization Header was found",
+		"pk_test_abc":      "Invalid key",
+		"sk_live_abc":      "Invalid key",
+		"sk_test_whatever": "",
+	} {
+		status, env := do(t, srv, key, "GET", "/bank", "")
+		if want == "" {
+			if status != http.StatusOK || env["status"] != true {
+				t.Errorf("key %q: got %d %v, want 200", key, status, env)
+			}
+			continue
+		}
+		if status != http.StatusUnauthorized || env["status"] != false || env["message"] != want {
+			t.Errorf("key %q: got %d %v, want 401 %q", key, status, env, want)
+		}
+	}
+}
+
+func TestServerUnknownRoute(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	if status, _ := do(t, srv, SecretKey, "GET", "/nothing", ""); status != http.StatusNotFound {
+		t.Errorf("got %d, want 404", status)
+	}
+	if status, _ := do(t, srv, SecretKey, "DELETE", "/customer", ""); status != http.StatusMethodNotAllowed {
+		t.Errorf("got %d, want 405", status)
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		if status, env := do(t, srv, SecretKey, "POST", "/customer", `{"email":"`+email+`"}`); status != http.StatusOK {
+			t.Fatalf("creating customer: %d %v", status, env)
+		}
+	}
+
+	_, env := do(t, srv, SecretKey, "GET", "/customer?perPage=2&page=2", "")
+	data := env["data"].([]interface{})
+	meta := env["meta"].(map[string]interface{})
+	if len(data) != 1 || data[0].(map[string]interface{})["email"] != "a@example.com" {
+		t.Errorf("page 2: got %v, want the oldest customer", data)
+	}
+	if meta["total"] != 3.0 || meta["pageCount"] != 2.0 || meta["skipped"] != 2.0 {
+		t.Errorf("got meta %v", meta)
+	}
+
+	_, env = do(t, srv, SecretKey, "GET", "/customer?use_cursor=true&perPage=2", "")
+	meta = env["meta"].(map[string]interface{})
+	next, _ := meta["next"].(string)
+	if len(env["data"].([]interface{})) != 2 || next == "" {
+		t.Fatalf("first cursor page: got %v", env)
+	}
+	_, env = do(t, srv, SecretKey, "GET", "/customer?use_cursor=true&perPage=2&next="+next, "")
+	meta = env["meta"].(map[string]interface{})
+	if len(env["data"].([]interface{})) != 1 || meta["next"] != nil || meta["previous"] == nil {
+		t.Errorf("last cursor page: got %v", env)
+	}
+}
+
+func TestServerUnwrapsFormValues(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	// url.Values bodies arrive as lists of strings
+	status, env := do(t, srv, SecretKey, "PUT", "/integration/payment_session_timeout", `{"timeout":["45"]}`)
+	if status != http.StatusOK {
+		t.Fatalf("got %d %v", status, env)
+	}
+	if got := env["data"].(map[string]interface{})["payment_session_timeout"]; got != 45.0 {
+		t.Errorf("got timeout %v, want 45", got)
+	}
+}
+
+func TestServerPay(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	status, env := do(t, srv, SecretKey, "POST", "/transaction/initialize",
+		`{"email":"pay@example.com","amount":500000,"reference":"order-1"}`)
+	if status != http.StatusOK {
+		t.Fatalf("got %d %v", status, env)
+	}
+	if err := srv.Pay("order-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Pay("order-1"); err == nil {
+		t.Error("expected paying twice to fail")
+	}
+
+	_, env = do(t, srv, SecretKey, "GET", "/transaction/verify/order-1", "")
+	txn := env["data"].(map[string]interface{})
+	if txn["status"] != "success" || txn["fees"] != 17500.0 {
+		t.Errorf("got %v, want a successful transaction with fees of 17500", txn)
+	}
+	if _, ok := txn["authorization"].(map[string]interface{})["authorization_code"]; !ok {
+		t.Errorf("expected an authorization, got %v", txn["authorization"])
+	}
+
+	_, env = do(t, srv, SecretKey, "GET", "/balance", "")
+	balance := env["data"].([]interface{})[0].(map[string]interface{})["balance"]
+	if balance != float64(DefaultBalance+500000-17500) {
+		t.Errorf("got balance %v", balance)
+	}
+}
+
+func TestServerErrorEnvelope(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	body := `{"email":"dup@example.com","amount":1000,"reference":"dup-ref"}`
+	do(t, srv, SecretKey, "POST", "/transaction/initialize", body)
+	status, env := do(t, srv, SecretKey, "POST", "/transaction/initialize", body)
+	if status != http.StatusBadRequest || env["status"] != false {
+		t.Fatalf("got %d %v, want 400", status, env)
+	}
+	if env["code"] != "duplicate_reference" || env["type"] != "validation_error" {
+		t.Errorf("got %v", env)
+	}
+}
@@ -0,0 +1,296 @@
+package paystacktest
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// object is a resource as Paystack returns it
+type object map[string]interface{}
+
+// integrationID is the ID of the fake integration owning every resource
+const integrationID = 100032
+
+// timeLayout is how Paystack formats timestamps
+const timeLayout = "2006-01-02T15:04:05.000Z"
+
+// collection holds the resources of one kind, in creation order
+type collection struct {
+	// prefix starts the codes of the resources, e.g. "CUS_"
+	prefix string
+	// codeField is the field holding the code, if resources have one
+	codeField string
+	// keys are other fields resources may be looked up by, e.g. email
+	keys []string
+	// snake names the timestamps created_at and updated_at, rather
+	// than createdAt and updatedAt
+	snake bool
+
+	items []object
+}
+
+// store is the state of the fake integration. It is guarded by Server.mu.
+type store struct {
+	nextID      int
+	collections map[string]*collection
+
+	balance        int64
+	sessionTimeout int
+	otpEnabled     bool
+
+	// pending maps the references of charges awaiting input, or timed out,
+	// to their flow
+	pending map[string]*chargeFlow
+	// refunded is the amount refunded of each transaction, by ID
+	refunded map[int]int64
+}
+
+func newStore() *store {
+	return &store{
+		nextID:         1,
+		balance:        DefaultBalance,
+		sessionTimeout: 30,
+		pending:        make(map[string]*chargeFlow),
+		refunded:       make(map[int]int64),
+		collections: map[string]*collection{
+			"customer":      {prefix: "CUS_", codeField: "customer_code", keys: []string{"email"}},
+			"transaction":   {keys: []string{"reference"}},
+			"authorization": {prefix: "AUTH_", codeField: "authorization_code"},
+			"plan":          {prefix: "PLN_", codeField: "plan_code"},
+			"subscription":  {prefix: "SUB_", codeField: "subscription_code"},
+			"page":          {keys: []string{"slug"}},
+			"product":       {prefix: "PROD_", codeField: "product_code"},
+			"subaccount":    {prefix: "ACCT_", codeField: "subaccount_code"},
+			"split":         {prefix: "SPL_", codeField: "split_code", snake: true},
+			"recipient":     {prefix: "RCP_", codeField: "recipient_code"},
+			"transfer":      {prefix: "TRF_", codeField: "transfer_code", keys: []string{"reference"}},
+			"refund":        {},
+			"dispute":       {},
+			"evidence":      {},
+			"dva":           {keys: []string{"account_number"}, snake: true},
+			"bulkcharge":    {prefix: "BCH_", codeField: "batch_code"},
+			"bulkitem":      {},
+			"settlement":    {},
+		},
+	}
+}
+
+func now() string {
+	return time.Now().UTC().Format(timeLayout)
+}
+
+// code returns a code for the resource with id, shaped like Paystack's
+func code(prefix string, id int) string {
+	s := strconv.FormatInt(int64(id)*7919+1e12, 36)
+	return prefix + strings.Repeat("0", max(0, 12-len(s))) + s
+}
+
+// create adds o to the resources of kind, giving it an ID, a code and
+// timestamps, and returns it.
+func (st *store) create(kind string, o object) object {
+	c := st.collections[kind]
+	id := st.nextID
+	st.nextID++
+
+	o["id"] = id
+	o["integration"] = integrationID
+	o["domain"] = "test"
+	if c.codeField != "" {
+		if _, ok := o[c.codeField]; !ok {
+			o[c.codeField] = code(c.prefix, id)
+		}
+	}
+	st.touch(kind, o, true)
+	c.items = append(c.items, o)
+	return o
+}
+
+// touch sets the update timestamp of o, and the creation one if created
+func (st *store) touch(kind string, o object, created bool) {
+	ts := now()
+	createdAt, updatedAt := "createdAt", "updatedAt"
+	if st.collections[kind].snake {
+		createdAt, updatedAt = "created_at", "updated_at"
+	}
+	if created {
+		o[createdAt] = ts
+	}
+	o[updatedAt] = ts
+}
+
+// find returns the resource of kind whose ID, code or key field is key
+func (st *store) find(kind, key string) object {
+	if key == "" {
+		return nil
+	}
+	c := st.collections[kind]
+	id, err := strconv.Atoi(key)
+	for _, o := range c.items {
+		if err == nil && o["id"] == id {
+			return o
+		}
+		if c.codeField != "" && o[c.codeField] == key {
+			return o
+		}
+		for _, k := range c.keys {
+			if o[k] == key {
+				return o
+			}
+		}
+	}
+	return nil
+}
+
+// all returns the resources of kind matching keep, newest first
+func (st *store) all(kind string, keep func(object) bool) []object {
+	items := st.collections[kind].items
+	out := make([]object, 0, len(items))
+	for i := len(items) - 1; i >= 0; i-- {
+		if keep == nil || keep(items[i]) {
+			out = append(out, items[i])
+		}
+	}
+	return out
+}
+
+// remove deletes o from the resources of kind
+func (st *store) remove(kind string, o object) {
+	c := st.collections[kind]
+	for i, item := range c.items {
+		if item["id"] == o["id"] {
+			c.items = append(c.items[:i], c.items[i+1:]...)
+			return
+		}
+	}
+}
+
+// listMeta is the pagination metadata of a list response
+type listMeta struct {
+	Total     int    `json:"total"`
+	Skipped   int    `json:"skipped"`
+	PerPage   int    `json:"perPage"`
+	Page      int    `json:"page"`
+	PageCount int    `json:"pageCount"`
+	Next      string `json:"next,omitempty"`
+	Previous  string `json:"previous,omitempty"`
+}
+
+// paginate answers with the page of items selected by the perPage and
+// page query parameters, or by the next and previous cursors when
+// use_cursor is set.
+func paginate(r *request, message string, items []object) response {
+	items = filterDates(r, items)
+	perPage, _ := strconv.Atoi(r.query("perPage"))
+	if perPage < 1 {
+		perPage = 50
+	}
+	pageNum, _ := strconv.Atoi(r.query("page"))
+	if pageNum < 1 {
+		pageNum = 1
+	}
+	if r.query("use_cursor") == "true" {
+		pageNum = 1
+		if c := r.query("next"); c != "" {
+			pageNum = cursorPage(c)
+		} else if c := r.query("previous"); c != "" {
+			pageNum = cursorPage(c)
+		}
+	}
+
+	start := min((pageNum-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	meta := listMeta{
+		Total:     len(items),
+		Skipped:   start,
+		PerPage:   perPage,
+		Page:      pageNum,
+		PageCount: int(math.Ceil(float64(len(items)) / float64(perPage))),
+	}
+	if r.query("use_cursor") == "true" {
+		if end < len(items) {
+			meta.Next = fmt.Sprintf("cursor_%d", pageNum+1)
+		}
+		if pageNum > 1 {
+			meta.Previous = fmt.Sprintf("cursor_%d", pageNum-1)
+		}
+	}
+	page := items[start:end]
+	if page == nil {
+		page = []object{}
+	}
+	return response{status: http.StatusOK, message: message, data: page, meta: meta}
+}
+
+func cursorPage(cursor string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(cursor, "cursor_"))
+	return max(n, 1)
+}
+
+// filterDates keeps the items created between the from and to query
+// parameters
+func filterDates(r *request, items []object) []object {
+	from, fromErr := parseDate(r.query("from"))
+	to, toErr := parseDate(r.query("to"))
+	if fromErr != nil && toErr != nil {
+		return items
+	}
+	out := items[:0:0]
+	for _, o := range items {
+		created, err := createdTime(o)
+		if err != nil {
+			continue
+		}
+		if fromErr == nil && created.Before(from) || toErr == nil && created.After(to) {
+			continue
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+func createdTime(o object) (time.Time, error) {
+	s, _ := o["createdAt"].(string)
+	if s == "" {
+		s, _ = o["created_at"].(string)
+	}
+	return time.Parse(timeLayout, s)
+}
+
+// matches reports whether the field key of o, formatted as a string, is
+// value. An empty value matches anything.
+func matches(o object, key, value string) bool {
+	return value == "" || toString(fieldValue(o[key])) == value
+}
+
+// fieldValue returns the ID of nested resources, and v otherwise
+func fieldValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case object:
+		return v["id"]
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	}
+	return v
+}
+
+// copyFields copies the listed body fields of r that are set into o
+func copyFields(r *request, o object, keys ...string) {
+	for _, k := range keys {
+		if v, ok := r.body[k]; ok {
+			o[k] = v
+		}
+	}
+}
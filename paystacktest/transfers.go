@@ -0,0 +1,214 @@
+package paystacktest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Transfer recipients
+
+func (s *Server) createRecipient(r *request) response {
+	typ := r.str("type")
+	if typ == "" {
+		// TransferRecipient.Type has no JSON name
+		typ = r.str("Type")
+	}
+	typ = strings.ToLower(typ)
+	switch typ {
+	case "nuban", "mobile_money", "basa", "ghipss":
+	case "":
+		return invalid("Recipient type is required")
+	default:
+		return invalid("Invalid recipient type")
+	}
+	if r.str("name") == "" {
+		return invalid("Recipient name is required")
+	}
+	bank := bankByCode(r.str("bank_code"))
+	if bank == nil {
+		return invalid("Cannot resolve account")
+	}
+	if len(r.str("account_number")) != 10 {
+		return invalid("Invalid account number")
+	}
+	recipient := object{
+		"type":     typ,
+		"name":     r.str("name"),
+		"currency": currency(r),
+		"active":   true,
+		"metadata": r.body["metadata"],
+		"details": object{
+			"account_number": r.str("account_number"),
+			"account_name":   strings.ToUpper(r.str("name")),
+			"bank_code":      bank["code"],
+			"bank_name":      bank["name"],
+		},
+	}
+	copyFields(r, recipient, "description")
+	return created("Transfer recipient created successfully", s.store.create("recipient", recipient))
+}
+
+func (s *Server) listRecipients(r *request) response {
+	return paginate(r, "Recipients retrieved", s.store.all("recipient", nil))
+}
+
+// Transfers
+
+func (s *Server) initiateTransfer(r *request) response {
+	if r.has("transfers") {
+		return s.bulkTransfer(r)
+	}
+	if source := r.str("source"); source != "balance" {
+		return invalid("Invalid transfer source")
+	}
+	transfer, resp := s.transfer(r.str("recipient"), r.int("amount"), currency(r), r.str("reference"))
+	if transfer == nil {
+		return resp
+	}
+	transfer["reason"] = r.str("reason")
+	if transfer["status"] == "otp" {
+		return ok("Transfer requires OTP to continue", transfer)
+	}
+	return ok("Transfer has been queued", transfer)
+}
+
+// transfer creates a transfer of amount to the recipient with
+// recipientCode. It completes at once, unless transfer OTPs are enabled.
+func (s *Server) transfer(recipientCode string, amount int64, currency, ref string) (object, response) {
+	recipient := s.store.find("recipient", recipientCode)
+	if recipient == nil {
+		return nil, notFound("Recipient")
+	}
+	if amount <= 0 {
+		return nil, invalid("Invalid amount")
+	}
+	if ref != "" && s.store.find("transfer", ref) != nil {
+		return nil, invalid("Duplicate Transfer Reference")
+	}
+	if amount > s.store.balance {
+		return nil, invalid("Your balance is not enough to fulfil this request")
+	}
+	transfer := s.store.create("transfer", object{
+		"source":    "balance",
+		"amount":    amount,
+		"currency":  currency,
+		"recipient": recipient,
+		"status":    "otp",
+		"failures":  nil,
+	})
+	if ref == "" {
+		ref = code("ref-", transfer["id"].(int))
+	}
+	transfer["reference"] = ref
+	if !s.store.otpEnabled {
+		s.completeTransfer(transfer)
+	}
+	return transfer, response{}
+}
+
+// completeTransfer debits the balance and marks transfer successful
+func (s *Server) completeTransfer(transfer object) {
+	s.store.balance -= transfer["amount"].(int64)
+	transfer["status"] = "success"
+	transfer["transferred_at"] = now()
+	s.store.touch("transfer", transfer, false)
+}
+
+func (s *Server) bulkTransfer(r *request) response {
+	items, _ := r.body["transfers"].([]interface{})
+	if len(items) == 0 {
+		return invalid("Please provide a list of transfers")
+	}
+	var data []object
+	for _, item := range items {
+		m, _ := item.(map[string]interface{})
+		amount, _ := m["amount"].(float64)
+		transfer, resp := s.transfer(toString(m["recipient"]), int64(amount), currency(r), toString(m["reference"]))
+		if transfer == nil {
+			return resp
+		}
+		data = append(data, object{
+			"recipient":     m["recipient"],
+			"amount":        transfer["amount"],
+			"currency":      transfer["currency"],
+			"reference":     transfer["reference"],
+			"transfer_code": transfer["transfer_code"],
+			"status":        transfer["status"],
+		})
+	}
+	return ok(fmt.Sprintf("%d transfers queued.", len(data)), data)
+}
+
+func (s *Server) finalizeTransfer(r *request) response {
+	transfer := s.store.find("transfer", r.str("transfer_code"))
+	if transfer == nil {
+		return notFound("Transfer")
+	}
+	if transfer["status"] != "otp" {
+		return invalid("Transfer is not currently awaiting OTP")
+	}
+	if r.str("otp") != TestOTP {
+		return invalid("Invalid OTP")
+	}
+	if transfer["amount"].(int64) > s.store.balance {
+		return invalid("Your balance is not enough to fulfil this request")
+	}
+	s.completeTransfer(transfer)
+	return ok("Transfer has been queued", transfer)
+}
+
+func (s *Server) resendTransferOTP(r *request) response {
+	transfer := s.store.find("transfer", r.str("transfer_code"))
+	if transfer == nil {
+		return notFound("Transfer")
+	}
+	if transfer["status"] != "otp" {
+		return invalid("Transfer is not currently awaiting OTP")
+	}
+	return ok("OTP has been resent", nil)
+}
+
+func (s *Server) enableTransferOTP(r *request) response {
+	s.store.otpEnabled = true
+	return ok("OTP requirement for transfers has been enabled", nil)
+}
+
+func (s *Server) disableTransferOTP(r *request) response {
+	return ok("OTP has been sent to mobile number ending with 4321", nil)
+}
+
+func (s *Server) finalizeDisableTransferOTP(r *request) response {
+	if r.str("otp") != TestOTP {
+		return invalid("Invalid OTP")
+	}
+	s.store.otpEnabled = false
+	return ok("OTP requirement for transfers has been disabled", nil)
+}
+
+func (s *Server) getTransfer(r *request) response {
+	transfer := s.store.find("transfer", r.param("code"))
+	if transfer == nil || transfer["reference"] == r.param("code") {
+		return notFound("Transfer")
+	}
+	return ok("Transfer retrieved", transfer)
+}
+
+func (s *Server) verifyTransfer(r *request) response {
+	transfer := s.store.find("transfer", r.param("reference"))
+	if transfer == nil || transfer["reference"] != r.param("reference") {
+		return notFound("Transfer")
+	}
+	return ok("Transfer retrieved", transfer)
+}
+
+func (s *Server) listTransfers(r *request) response {
+	transfers := s.store.all("transfer", func(t object) bool {
+		return matches(t, "recipient", r.query("recipient")) &&
+			matches(t, "status", r.query("status"))
+	})
+	return paginate(r, "Transfers retrieved", transfers)
+}
+
+func (s *Server) checkBalance(r *request) response {
+	return ok("Balances retrieved", []object{{"currency": "NGN", "balance": s.store.balance}})
+}
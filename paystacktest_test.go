@@ -0,0 +1,409 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rpip/paystack-go/paystacktest"
+)
+
+func newFakeClient(t *testing.T) (*Client, *paystacktest.Server) {
+	t.Helper()
+	srv := paystacktest.NewServer()
+	t.Cleanup(srv.Close)
+	client := NewClient(paystacktest.SecretKey, srv.Client())
+	client.LoggingEnabled = false
+	return client, srv
+}
+
+func TestFakeCustomers(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	cust, err := client.Customer.Create(&Customer{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cust.ID == 0 || cust.CustomerCode == "" || cust.CreatedAt.IsZero() {
+		t.Errorf("got %+v, want an ID, a code and a creation time", cust)
+	}
+
+	cust.Phone = "+2348000000000"
+	if _, err := client.Customer.Update(cust); err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.Customer.Get(cust.CustomerCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Phone != "+2348000000000" {
+		t.Errorf("got phone %q", got.Phone)
+	}
+
+	if _, err := client.Customer.SetRiskAction(cust.CustomerCode, "deny"); err != nil {
+		t.Fatal(err)
+	}
+	list, err := client.Customer.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Values) != 1 || list.Values[0].RiskAction != "deny" || list.Meta.Total != 1 {
+		t.Errorf("got %+v", list)
+	}
+
+	_, err = client.Customer.Get("CUS_missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %v, want ErrNotFound", err)
+	}
+}
+
+func TestFakeTransactionsAndSubscriptions(t *testing.T) {
+	client, srv := newFakeClient(t)
+
+	plan, err := client.Plan.Create(&Plan{Name: "Monthly", Amount: NewMoney(5000, "NGN"), Interval: "monthly"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Transaction.Initialize(&TransactionRequest{
+		Email:     "sub@example.com",
+		Amount:    NewMoney(5000, "NGN"),
+		Reference: "first-payment",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp["authorization_url"] == nil {
+		t.Errorf("got %v, want an authorization URL", resp)
+	}
+	_, err = client.Transaction.Initialize(&TransactionRequest{
+		Email:     "sub@example.com",
+		Amount:    NewMoney(5000, "NGN"),
+		Reference: "first-payment",
+	})
+	if !errors.Is(err, ErrDuplicateReference) {
+		t.Errorf("got %v, want ErrDuplicateReference", err)
+	}
+
+	if err := srv.Pay("first-payment"); err != nil {
+		t.Fatal(err)
+	}
+	txn, err := client.Transaction.Verify("first-payment")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txn.Status != "success" || txn.Amount != NewMoney(5000, "NGN") || txn.PaidAt.IsZero() {
+		t.Errorf("got %+v, want a paid transaction of NGN 5000", txn)
+	}
+
+	charged, err := client.Transaction.ChargeAuthorization(&TransactionRequest{
+		Email:             "sub@example.com",
+		Amount:            NewMoney(100, "NGN"),
+		AuthorizationCode: txn.Authorization.AuthorizationCode,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if charged.Status != "success" {
+		t.Errorf("got status %q", charged.Status)
+	}
+
+	sub, err := client.Subscription.Create(&SubscriptionRequest{Customer: "sub@example.com", Plan: plan.PlanCode})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Subscription.Disable(sub.SubscriptionCode, sub.EmailToken); err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.Subscription.Get(sub.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "cancelled" {
+		t.Errorf("got status %q, want cancelled", got.Status)
+	}
+
+	txns, err := client.Transaction.ListWithParams(&TransactionListParams{Status: "success"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txns.Values) != 2 {
+		t.Errorf("got %d successful transactions, want 2", len(txns.Values))
+	}
+}
+
+func TestFakeRefundsAndDisputes(t *testing.T) {
+	client, srv := newFakeClient(t)
+
+	if _, err := client.Transaction.Initialize(&TransactionRequest{
+		Email: "refund@example.com", Amount: NewMoney(1000, "NGN"), Reference: "to-refund",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Pay("to-refund"); err != nil {
+		t.Fatal(err)
+	}
+
+	refund, err := client.Refund.CreateRefund(&RefundRequest{Transaction: "to-refund", Amount: NewMoney(400, "NGN")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refund.Amount != NewMoney(400, "NGN") || refund.Status != "pending" {
+		t.Errorf("got %+v", refund)
+	}
+	_, err = client.Refund.CreateRefund(&RefundRequest{Transaction: "to-refund", Amount: NewMoney(700, "NGN")})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("got %v, want a validation error for refunding more than was paid", err)
+	}
+
+	id, err := srv.OpenDispute("to-refund")
+	if err != nil {
+		t.Fatal(err)
+	}
+	evidence, err := client.Dispute.AddDisputeEvidence(id, &AddDisputeEvidenceRequest{
+		CustomerEmail:  "refund@example.com",
+		CustomerName:   "Customer",
+		CustomerPhone:  "08000000000",
+		ServiceDetails: "Delivered",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dispute, err := client.Dispute.ResolveDispute(id, &ResolveDisputeRequest{
+		Resolution:       "declined",
+		Message:          "Goods were delivered",
+		UploadedFilename: "receipt.pdf",
+		Evidence:         evidence.Id,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dispute.Status != "resolved" || dispute.ResolvedAt.IsZero() {
+		t.Errorf("got %+v, want a resolved dispute", dispute)
+	}
+}
+
+func TestFakeTransfers(t *testing.T) {
+	client, _ := newFakeClient(t)
+	ctx := context.Background()
+
+	recipient, err := client.Transfer.CreateRecipient(&TransferRecipient{
+		Type:          "nuban",
+		Name:          "Supplier",
+		AccountNumber: "0123456789",
+		BankCode:      "058",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idem := NewIdempotency(nil, nil)
+	req := &TransferRequest{Source: "balance", Amount: NewMoney(200, "NGN"), Recipient: recipient.RecipientCode}
+	first, err := idem.InitiateTransfer(ctx, client, "payout-1", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := idem.InitiateTransfer(ctx, client, "payout-1", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.TransferCode != again.TransferCode || first.Status != "success" {
+		t.Errorf("got %+v and %+v, want the same successful transfer", first, again)
+	}
+
+	_, err = client.Transfer.Initiate(&TransferRequest{
+		Source: "balance", Amount: NewMoney(paystacktest.DefaultBalance, "NGN"), Recipient: recipient.RecipientCode,
+	})
+	if !errors.Is(err, ErrInsufficientBalance) {
+		t.Errorf("got %v, want ErrInsufficientBalance", err)
+	}
+
+	if _, err := client.Transfer.EnableOTP(); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := client.Transfer.Initiate(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.Status != "otp" {
+		t.Fatalf("got status %q, want otp", pending.Status)
+	}
+	if _, err := client.Transfer.Finalize(pending.TransferCode, paystacktest.TestOTP); err != nil {
+		t.Fatal(err)
+	}
+	done, err := client.Transfer.Get(pending.TransferCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done.Status != "success" {
+		t.Errorf("got status %q, want success", done.Status)
+	}
+}
+
+func TestFakeSplitsAndDedicatedAccounts(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	acct, err := client.SubAccount.Create(&SubAccount{
+		BusinessName:     "Vendor",
+		SettlementBank:   "044",
+		AccountNumber:    "0000000000",
+		PercentageCharge: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	split, err := client.Split.CreateSplit(&SplitRequest{
+		Name:        "Vendors",
+		Type:        "percentage",
+		Currency:    "NGN",
+		Subaccounts: []BeneficiaryAccountRequest{{SubAccountCode: acct.SubAccountCode, Share: 20}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if split.TotalSubAccounts != 1 || split.Subaccounts[0].Subaccount.SubAccountCode != acct.SubAccountCode {
+		t.Errorf("got %+v", split)
+	}
+
+	cust, err := client.Customer.Create(&Customer{FirstName: "Dee", LastName: "Vee", Email: "dva@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dva, err := client.DedicatedVirtualAccount.Create(&DedicatedVirtualAccountRequest{
+		Customer:      cust.ID,
+		PreferredBank: "wema-bank",
+		SplitCode:     split.SplitCode,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dva.AccountNumber == "" || !dva.Active || dva.Customer.Email != "dva@example.com" || dva.SplitConfig.SplitCode != split.SplitCode {
+		t.Errorf("got %+v", dva)
+	}
+
+	if _, err := client.DedicatedVirtualAccount.Deactivate(dva.Id); err != nil {
+		t.Fatal(err)
+	}
+	active := true
+	list, err := client.DedicatedVirtualAccount.List(&DVAListFilter{Active: &active})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Values) != 0 {
+		t.Errorf("got %d active accounts, want 0", len(list.Values))
+	}
+}
+
+func TestFakeCatalog(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	product, err := client.Product.Create(&ProductRequest{
+		Name: "Mug", Description: "A mug", Price: NewMoney(2500, "NGN"), Currency: "NGN",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if product.Price != NewMoney(2500, "NGN") {
+		t.Errorf("got price %v", product.Price)
+	}
+
+	page, err := client.Page.Create(&Page{Name: "Donations", Amount: NewMoney(1000, "NGN")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	page.Name = "Gifts"
+	if _, err := client.Page.Update(page); err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.Page.Get(page.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Gifts" || got.Slug != "donations" {
+		t.Errorf("got %+v", got)
+	}
+
+	banks, err := client.Bank.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(banks.Values) == 0 {
+		t.Error("expected banks")
+	}
+}
+
+func TestFakeFaults(t *testing.T) {
+	client, srv := newFakeClient(t)
+	client.Retry = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	srv.Inject("GET", "/bank", paystacktest.ServerError(503).Times(2))
+	if _, err := client.Bank.List(); err != nil {
+		t.Fatalf("got %v, want the third attempt to succeed", err)
+	}
+	if n := srv.Calls("GET", "/bank"); n != 3 {
+		t.Errorf("got %d calls, want 3", n)
+	}
+
+	srv.Inject("GET", "/customer", paystacktest.RateLimited(0).Always())
+	if _, err := client.Customer.List(); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("got %v, want ErrRateLimited", err)
+	}
+	srv.ClearFaults()
+
+	srv.Inject("GET", "/bank", paystacktest.StatusFalse("Banks unavailable"), paystacktest.MalformedJSON())
+	if _, err := client.Bank.List(); err == nil || !strings.Contains(err.Error(), "Banks unavailable") {
+		t.Errorf("got %v, want the message of the failed envelope", err)
+	}
+	if _, err := client.Bank.List(); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
+
+func TestCheckBalanceErrors(t *testing.T) {
+	client, srv := newFakeClient(t)
+
+	srv.Inject("GET", "/balance", paystacktest.StatusFalse("Balance unavailable"), paystacktest.MalformedJSON())
+	if _, err := client.CheckBalance(); err == nil || !strings.Contains(err.Error(), "Balance unavailable") {
+		t.Errorf("got %v, want the message of the failed envelope", err)
+	}
+	if _, err := client.CheckBalance(); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
+
+func TestFakeChargeScenarios(t *testing.T) {
+	client, _ := newFakeClient(t)
+
+	resp, err := client.Charge.Create(&ChargeRequest{
+		Email:  "card@example.com",
+		Amount: NewMoney(100, "NGN"),
+		Card:   &Card{Number: paystacktest.CardSendPINAndOTP},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, _ := resp["reference"].(string)
+	if resp["status"] != "send_pin" {
+		t.Fatalf("got %v, want send_pin", resp)
+	}
+	if resp, err = client.Charge.SubmitPIN("1234", ref); err != nil || resp["status"] != "send_otp" {
+		t.Fatalf("got %v %v, want send_otp", resp, err)
+	}
+	if resp, err = client.Charge.SubmitOTP(paystacktest.TestOTP, ref); err != nil || resp["status"] != "success" {
+		t.Fatalf("got %v %v, want success", resp, err)
+	}
+
+	resp, err = client.Charge.Create(&ChargeRequest{
+		Email:  "card@example.com",
+		Amount: NewMoney(100, "NGN"),
+		Card:   &Card{Number: paystacktest.CardTimeout},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = client.Charge.CheckPending(resp["reference"].(string))
+	if err != nil || resp["status"] != "timeout" {
+		t.Errorf("got %v %v, want timeout", resp, err)
+	}
+}
@@ -1,6 +1,9 @@
 package paystack
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // PlanService handles operations related to the plan
 // For more details see https://developers.paystack.co/v1.0/reference#create-plan
@@ -9,69 +12,104 @@ type PlanService service
 // Plan represents a
 // For more details see https://developers.paystack.co/v1.0/reference#create-plan
 type Plan struct {
-	ID                int     `json:"id,omitempty"`
-	CreatedAt         string  `json:"createdAt,omitempty"`
-	UpdatedAt         string  `json:"updatedAt,omitempty"`
-	Domain            string  `json:"domain,omitempty"`
-	Integration       int     `json:"integration,omitempty"`
-	Name              string  `json:"name,omitempty"`
-	Description       string  `json:"description,omitempty"`
-	PlanCode          string  `json:"plan_code,omitempty"`
-	Amount            float32 `json:"amount,omitempty"`
-	Interval          string  `json:"interval,omitempty"`
-	SendInvoices      bool    `json:"send_invoices,omitempty"`
-	SendSMS           bool    `json:"send_sms,omitempty"`
-	Currency          string  `json:"currency,omitempty"`
-	InvoiceLimit      float32 `json:"invoice_limit,omitempty"`
-	HostedPage        string  `json:"hosted_page,omitempty"`
-	HostedPageURL     string  `json:"hosted_page_url,omitempty"`
-	HostedPageSummary string  `json:"hosted_page_summary,omitempty"`
+	ID                int       `json:"id,omitempty"`
+	CreatedAt         Timestamp `json:"createdAt,omitzero"`
+	UpdatedAt         Timestamp `json:"updatedAt,omitzero"`
+	Domain            string    `json:"domain,omitempty"`
+	Integration       int       `json:"integration,omitempty"`
+	Name              string    `json:"name,omitempty"`
+	Description       string    `json:"description,omitempty"`
+	PlanCode          string    `json:"plan_code,omitempty"`
+	Amount            Money     `json:"amount,omitzero"`
+	Interval          string    `json:"interval,omitempty"`
+	SendInvoices      bool      `json:"send_invoices,omitempty"`
+	SendSMS           bool      `json:"send_sms,omitempty"`
+	Currency          string    `json:"currency,omitempty"`
+	InvoiceLimit      float32   `json:"invoice_limit,omitempty"`
+	HostedPage        string    `json:"hosted_page,omitempty"`
+	HostedPageURL     string    `json:"hosted_page_url,omitempty"`
+	HostedPageSummary string    `json:"hosted_page_summary,omitempty"`
 }
 
-// PlanList is a list object for Plans.
-type PlanList struct {
-	Meta   ListMeta
-	Values []Plan `json:"data"`
+// UnmarshalJSON decodes p, accepting camelCase or snake_case timestamp keys.
+func (p *Plan) UnmarshalJSON(data []byte) error {
+	type plan Plan
+	return unmarshalTimestamps(data, (*plan)(p))
 }
 
+// PlanList is a list object for Plans.
+type PlanList = List[Plan]
+
 // Create creates a new plan
 // For more details see https://developers.paystack.co/v1.0/reference#create-plan
 func (s *PlanService) Create(plan *Plan) (*Plan, error) {
+	return s.CreateContext(context.Background(), plan)
+}
+
+// CreateContext is like Create but uses ctx for the request.
+func (s *PlanService) CreateContext(ctx context.Context, plan *Plan) (*Plan, error) {
 	u := fmt.Sprintf("/plan")
 	plan2 := &Plan{}
-	err := s.client.Call("POST", u, plan, plan2)
+	err := s.client.call(ctx, "Plan.Create", "POST", u, plan, plan2)
 	return plan2, err
 }
 
 // Update updates a plan's properties.
 // For more details see https://developers.paystack.co/v1.0/reference#update-plan
 func (s *PlanService) Update(plan *Plan) (Response, error) {
+	return s.UpdateContext(context.Background(), plan)
+}
+
+// UpdateContext is like Update but uses ctx for the request.
+func (s *PlanService) UpdateContext(ctx context.Context, plan *Plan) (Response, error) {
 	u := fmt.Sprintf("plan/%d", plan.ID)
 	resp := Response{}
-	err := s.client.Call("PUT", u, plan, &resp)
+	err := s.client.call(ctx, "Plan.Update", "PUT", u, plan, &resp)
 	return resp, err
 }
 
 // Get returns the details of a plan.
 // For more details see https://developers.paystack.co/v1.0/reference#fetch-plan
 func (s *PlanService) Get(id int) (*Plan, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *PlanService) GetContext(ctx context.Context, id int) (*Plan, error) {
 	u := fmt.Sprintf("/plan/%d", id)
 	plan2 := &Plan{}
-	err := s.client.Call("GET", u, nil, plan2)
+	err := s.client.call(ctx, "Plan.Get", "GET", u, nil, plan2)
 	return plan2, err
 }
 
 // List returns a list of plans.
 // For more details see https://developers.paystack.co/v1.0/reference#list-plans
 func (s *PlanService) List() (*PlanList, error) {
-	return s.ListN(10, 0)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *PlanService) ListContext(ctx context.Context) (*PlanList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // ListN returns a list of plans
 // For more details see https://developers.paystack.co/v1.0/reference#list-plans
 func (s *PlanService) ListN(count, offset int) (*PlanList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *PlanService) ListNContext(ctx context.Context, count, offset int) (*PlanList, error) {
 	u := paginateURL("/plan", count, offset)
 	plan2 := &PlanList{}
-	err := s.client.Call("GET", u, nil, plan2)
+	err := s.client.call(ctx, "Plan.ListN", "GET", u, nil, plan2)
 	return plan2, err
 }
+
+// Iter returns an iterator over all plans, fetching perPage of them at a time.
+func (s *PlanService) Iter(ctx context.Context, perPage int) *Iterator[Plan] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Plan], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
@@ -3,10 +3,12 @@ package paystack
 import "testing"
 
 func TestPlanCRUD(t *testing.T) {
+	useClient(t)
+
 	plan1 := &Plan{
 		Name:     "Monthly retainer",
 		Interval: "monthly",
-		Amount:   500000,
+		Amount:   NewMoney(500000, "NGN"),
 	}
 
 	// create the plan
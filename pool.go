@@ -0,0 +1,239 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultLookupTimeout bounds key lookups when ClientPool.LookupTimeout is zero
+const defaultLookupTimeout = 30 * time.Second
+
+// KeyFunc returns the secret key of a merchant, e.g. from a secrets store.
+type KeyFunc func(ctx context.Context, merchantID string) (string, error)
+
+// ClientPool holds one Client per merchant for platforms where each
+// merchant has its own Paystack key. Clients are created on first use and
+// share a single HTTP transport, so connections to Paystack are reused
+// across merchants.
+//
+// A ClientPool is safe for concurrent use by multiple goroutines. Its
+// exported fields must not be modified once it is in use.
+type ClientPool struct {
+	// MerchantOptions, when set, returns options applied after the shared
+	// ones when a merchant's client is created, e.g. to give each merchant
+	// its own RateLimiter or Logger.
+	MerchantOptions func(merchantID string) []Option
+
+	// IdleTimeout evicts clients that have not been used for longer than
+	// it. Clients are kept until evicted explicitly when it is zero.
+	IdleTimeout time.Duration
+
+	// LookupTimeout bounds each key lookup, which is not cancelled with the
+	// callers of Get. A lookup that takes longer fails with
+	// context.DeadlineExceeded and the next Get tries again. It is 30
+	// seconds when zero.
+	LookupTimeout time.Duration
+
+	keys       KeyFunc
+	opts       []Option
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	clients   map[string]*poolEntry
+	lastSweep time.Time
+}
+
+// poolEntry is a merchant's client, which is ready once the ready channel
+// is closed
+type poolEntry struct {
+	ready    chan struct{}
+	client   *Client
+	err      error
+	lastUsed atomic.Int64 // unix nanoseconds
+}
+
+// NewClientPool returns a pool that looks up merchant keys with keys and
+// creates clients with opts. Unless opts include WithHTTPClient, the
+// clients share an http.Client whose transport keeps enough idle
+// connections to Paystack for concurrent use by many merchants.
+//
+// Options shared by every merchant must not include WithRateLimiter,
+// WithCircuitBreaker or WithCache, whose state is per merchant: give them
+// through MerchantOptions instead.
+func NewClientPool(keys KeyFunc, opts ...Option) (*ClientPool, error) {
+	if keys == nil {
+		return nil, errors.New("paystack: key function must not be nil")
+	}
+	// validate the options once, rather than on every client created
+	o := &clientOptions{}
+	o.baseURL, _ = url.Parse(baseURL)
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	if o.rateLimiter != nil || o.circuitBreaker != nil || o.cache != nil {
+		return nil, errors.New("paystack: rate limiters, circuit breakers and caches are per merchant; set them with MerchantOptions")
+	}
+
+	p := &ClientPool{
+		keys:    keys,
+		opts:    opts,
+		clients: make(map[string]*poolEntry),
+	}
+	if o.httpClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = 100
+		p.httpClient = &http.Client{Timeout: defaultHTTPTimeout, Transport: transport}
+	}
+	return p, nil
+}
+
+// Get returns the client of merchantID, creating it if needed.
+// Concurrent calls for the same merchant share a single key lookup, which
+// is not cancelled when ctx of one caller is done but gives up after
+// LookupTimeout.
+func (p *ClientPool) Get(ctx context.Context, merchantID string) (*Client, error) {
+	now := time.Now()
+	p.mu.Lock()
+	p.sweep(now)
+	e, ok := p.clients[merchantID]
+	if !ok {
+		e = &poolEntry{ready: make(chan struct{})}
+		p.clients[merchantID] = e
+	}
+	e.lastUsed.Store(now.UnixNano())
+	p.mu.Unlock()
+
+	if !ok {
+		go p.create(context.WithoutCancel(ctx), merchantID, e)
+	}
+
+	select {
+	case <-e.ready:
+		return e.client, e.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Rotate replaces the key of merchantID. Calls already made with the old
+// client complete with the old key; later calls to Get return a client
+// using key.
+func (p *ClientPool) Rotate(merchantID, key string) error {
+	c, err := p.newClient(merchantID, key)
+	if err != nil {
+		return err
+	}
+	e := &poolEntry{ready: make(chan struct{}), client: c}
+	e.lastUsed.Store(time.Now().UnixNano())
+	close(e.ready)
+
+	p.mu.Lock()
+	p.clients[merchantID] = e
+	p.mu.Unlock()
+	return nil
+}
+
+// Evict removes the client of merchantID. The next call to Get looks up
+// the merchant's key again.
+func (p *ClientPool) Evict(merchantID string) {
+	p.mu.Lock()
+	delete(p.clients, merchantID)
+	p.mu.Unlock()
+}
+
+// Len returns the number of merchants with a client in the pool.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.clients)
+}
+
+// CloseIdleConnections closes idle connections of the shared transport.
+func (p *ClientPool) CloseIdleConnections() {
+	if p.httpClient != nil {
+		p.httpClient.CloseIdleConnections()
+	}
+}
+
+// create looks up the key of merchantID and makes the client of e
+func (p *ClientPool) create(ctx context.Context, merchantID string, e *poolEntry) {
+	timeout := p.LookupTimeout
+	if timeout <= 0 {
+		timeout = defaultLookupTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	key, err := p.lookup(ctx, merchantID)
+	if err == nil {
+		e.client, err = p.newClient(merchantID, key)
+	}
+	e.err = err
+	if err != nil {
+		p.remove(merchantID, e)
+	}
+	close(e.ready)
+}
+
+// lookup calls the key function, giving up when ctx is done even if the key
+// function does not return
+func (p *ClientPool) lookup(ctx context.Context, merchantID string) (string, error) {
+	type result struct {
+		key string
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		key, err := p.keys(ctx, merchantID)
+		done <- result{key, err}
+	}()
+	select {
+	case r := <-done:
+		return r.key, r.err
+	case <-ctx.Done():
+		return "", fmt.Errorf("paystack: looking up the key of merchant %s: %w", merchantID, ctx.Err())
+	}
+}
+
+func (p *ClientPool) newClient(merchantID, key string) (*Client, error) {
+	opts := make([]Option, 0, len(p.opts)+2)
+	if p.httpClient != nil {
+		opts = append(opts, WithHTTPClient(p.httpClient))
+	}
+	opts = append(opts, p.opts...)
+	if p.MerchantOptions != nil {
+		opts = append(opts, p.MerchantOptions(merchantID)...)
+	}
+	return NewClientWithOptions(key, opts...)
+}
+
+// remove deletes e from the pool if it is still the entry of merchantID
+func (p *ClientPool) remove(merchantID string, e *poolEntry) {
+	p.mu.Lock()
+	if p.clients[merchantID] == e {
+		delete(p.clients, merchantID)
+	}
+	p.mu.Unlock()
+}
+
+// sweep evicts idle clients, at most once per IdleTimeout. p.mu must be held.
+func (p *ClientPool) sweep(now time.Time) {
+	if p.IdleTimeout <= 0 || now.Sub(p.lastSweep) < p.IdleTimeout {
+		return
+	}
+	p.lastSweep = now
+	cutoff := now.Add(-p.IdleTimeout).UnixNano()
+	for id, e := range p.clients {
+		if e.lastUsed.Load() < cutoff {
+			delete(p.clients, id)
+		}
+	}
+}
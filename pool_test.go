@@ -0,0 +1,192 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientPool(t *testing.T) {
+	var auth []string
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		auth = append(auth, r.Header.Get("Authorization"))
+		mu.Unlock()
+		fmt.Fprint(w, `{"status":true,"message":"ok","data":{"id":1}}`)
+	}))
+	defer srv.Close()
+
+	var lookups atomic.Int32
+	keys := func(ctx context.Context, merchantID string) (string, error) {
+		lookups.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return "sk_test_" + merchantID, nil
+	}
+	pool, err := NewClientPool(keys, WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.MerchantOptions = func(merchantID string) []Option {
+		return []Option{WithRateLimiter(NewRateLimiter(10, 5))}
+	}
+
+	// concurrent first use of a merchant shares one key lookup
+	var wg sync.WaitGroup
+	clients := make([]*Client, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = pool.Get(context.Background(), "m1")
+		}(i)
+	}
+	wg.Wait()
+	if lookups.Load() != 1 {
+		t.Errorf("Expected 1 key lookup, got %d", lookups.Load())
+	}
+	for _, c := range clients[1:] {
+		if c != clients[0] {
+			t.Fatal("Expected every caller to get the same client")
+		}
+	}
+
+	c1 := clients[0]
+	c2, err := pool.Get(context.Background(), "m2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1.client.Transport == nil || c1.client.Transport != c2.client.Transport {
+		t.Errorf("Expected clients to share one transport")
+	}
+	if c1.RateLimiter == c2.RateLimiter {
+		t.Errorf("Expected each merchant to have its own rate limiter")
+	}
+
+	if err := pool.Rotate("m1", "sk_test_rotated"); err != nil {
+		t.Fatal(err)
+	}
+	c1.Transaction.Verify("ref1")
+	rotated, _ := pool.Get(context.Background(), "m1")
+	rotated.Transaction.Verify("ref1")
+	if auth[0] != "Bearer sk_test_m1" || auth[1] != "Bearer sk_test_rotated" {
+		t.Errorf("Expected old client to keep its key and new client to use the rotated key, got %v", auth)
+	}
+
+	pool.Evict("m2")
+	if pool.Len() != 1 {
+		t.Errorf("Expected 1 client after eviction, got %d", pool.Len())
+	}
+	if c, _ := pool.Get(context.Background(), "m2"); c == c2 || lookups.Load() != 3 {
+		t.Errorf("Expected an evicted merchant's key to be looked up again")
+	}
+}
+
+func TestClientPoolKeyError(t *testing.T) {
+	fail := true
+	pool, err := NewClientPool(func(ctx context.Context, merchantID string) (string, error) {
+		if fail {
+			return "", errors.New("secret store unavailable")
+		}
+		return "sk_test_key", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := pool.Get(context.Background(), "m1"); err == nil {
+		t.Fatal("Expected key lookup error")
+	}
+	fail = false
+	if _, err := pool.Get(context.Background(), "m1"); err != nil {
+		t.Errorf("Expected a failed lookup not to be cached, got %v", err)
+	}
+
+	if _, err := NewClientPool(nil); err == nil {
+		t.Errorf("Expected a nil key function to be rejected")
+	}
+	if _, err := NewClientPool(pool.keys, WithBaseURL("ftp://example.com")); err == nil {
+		t.Errorf("Expected invalid options to be rejected")
+	}
+	for _, opt := range []Option{
+		WithRateLimiter(NewRateLimiter(10, 5)),
+		WithCircuitBreaker(NewCircuitBreaker()),
+		WithCache(NewLRUCache(10), nil),
+	} {
+		if _, err := NewClientPool(pool.keys, opt); err == nil {
+			t.Errorf("Expected per-merchant state shared by every merchant to be rejected")
+		}
+	}
+}
+
+func TestClientPoolLookupOutlivesCaller(t *testing.T) {
+	release := make(chan struct{})
+	pool, _ := NewClientPool(func(ctx context.Context, merchantID string) (string, error) {
+		<-release
+		return "sk_test_key", ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := pool.Get(ctx, "m1")
+		first <- err
+	}()
+	second := make(chan error)
+	go func() {
+		_, err := pool.Get(context.Background(), "m1")
+		second <- err
+	}()
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancelled caller to return context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("Expected the other caller to get the client, got %v", err)
+	}
+}
+
+func TestClientPoolLookupTimeout(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	defer close(release)
+	pool, _ := NewClientPool(func(ctx context.Context, merchantID string) (string, error) {
+		if calls.Add(1) == 1 {
+			<-release // hangs, ignoring ctx
+		}
+		return "sk_test_key", nil
+	})
+	pool.LookupTimeout = 20 * time.Millisecond
+
+	if _, err := pool.Get(context.Background(), "m1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a hanging lookup to time out, got %v", err)
+	}
+	if pool.Len() != 0 {
+		t.Errorf("Expected the timed out lookup to be removed, got %d clients", pool.Len())
+	}
+	if _, err := pool.Get(context.Background(), "m1"); err != nil {
+		t.Errorf("Expected the next Get to look up the key again, got %v", err)
+	}
+}
+
+func TestClientPoolIdleEviction(t *testing.T) {
+	pool, _ := NewClientPool(func(ctx context.Context, merchantID string) (string, error) {
+		return "sk_test_key", nil
+	})
+	pool.IdleTimeout = 20 * time.Millisecond
+
+	pool.Get(context.Background(), "idle")
+	time.Sleep(30 * time.Millisecond)
+	pool.Get(context.Background(), "active")
+	if pool.Len() != 1 {
+		t.Errorf("Expected the idle client to be evicted, got %d clients", pool.Len())
+	}
+}
@@ -1,6 +1,9 @@
 package paystack
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type ProductService service
 
@@ -8,7 +11,7 @@ type Product struct {
 	Name              string      `json:"name,omitempty"`
 	Description       string      `json:"description,omitempty"`
 	Currency          string      `json:"currency,omitempty"`
-	Price             int         `json:"price,omitempty"`
+	Price             Money       `json:"price,omitzero"`
 	Quantity          int         `json:"quantity,omitempty"`
 	IsShippable       bool        `json:"is_shippable,omitempty"`
 	Unlimited         bool        `json:"unlimited,omitempty"`
@@ -26,63 +29,98 @@ type Product struct {
 	MaximumOrderable  int         `json:"maximum_orderable,omitempty"`
 	LowStockAlert     bool        `json:"low_stock_alert,omitempty"`
 	Id                int         `json:"id,omitempty"`
-	CreatedAt         string      `json:"createdAt,omitempty"`
-	UpdatedAt         string      `json:"updatedAt,omitempty"`
+	CreatedAt         Timestamp   `json:"createdAt,omitzero"`
+	UpdatedAt         Timestamp   `json:"updatedAt,omitzero"`
+}
+
+// UnmarshalJSON decodes p, accepting camelCase or snake_case timestamp keys.
+func (p *Product) UnmarshalJSON(data []byte) error {
+	type product Product
+	return unmarshalTimestamps(data, (*product)(p))
 }
 
 type ProductRequest struct {
 	Name        string `json:"name,omitempty"`
 	Description string `json:"description,omitempty"`
-	Price       string `json:"price,omitempty"`
+	Price       Money  `json:"price,omitzero"`
 	Currency    string `json:"currency,omitempty"`
 	Unlimited   bool   `json:"unlimited,omitempty"` // Optional
 	Quantity    int    `json:"quantity,omitempty"`  // Optional
 }
 
 // ProductList is a list object for Products.
-type ProductList struct {
-	Meta   ListMeta
-	Values []Product `json:"data"`
-}
+type ProductList = List[Product]
 
 // Create a product on your integration
 // For more details see https://paystack.com/docs/api/product/#create
 func (s *ProductService) Create(request *ProductRequest) (*Product, error) {
+	return s.CreateContext(context.Background(), request)
+}
+
+// CreateContext is like Create but uses ctx for the request.
+func (s *ProductService) CreateContext(ctx context.Context, request *ProductRequest) (*Product, error) {
 	u := "/product"
 	product := &Product{}
-	err := s.client.Call("POST", u, request, product)
+	err := s.client.call(ctx, "Product.Create", "POST", u, request, product)
 	return product, err
 }
 
 // List returns a list of Products.
 // For more details see https://paystack.com/docs/api/product/#list
 func (s *ProductService) List() (*ProductList, error) {
-	return s.ListN(10, 1)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *ProductService) ListContext(ctx context.Context) (*ProductList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // ListN returns a list of Products
 // For more details see https://paystack.com/docs/api/product/#list
 func (s *ProductService) ListN(count, offset int) (*ProductList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *ProductService) ListNContext(ctx context.Context, count, offset int) (*ProductList, error) {
 	u := paginateURL("/product", count, offset)
 	products := &ProductList{}
-	err := s.client.Call("GET", u, nil, products)
+	err := s.client.call(ctx, "Product.ListN", "GET", u, nil, products)
 	return products, err
 }
 
+// Iter returns an iterator over all products, fetching perPage of them at a time.
+func (s *ProductService) Iter(ctx context.Context, perPage int) *Iterator[Product] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Product], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
+
 // Get details of Product with the specified id
 // For more details see https://paystack.com/docs/api/product/#fetch
 func (s *ProductService) Get(id int) (*Product, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *ProductService) GetContext(ctx context.Context, id int) (*Product, error) {
 	url := fmt.Sprintf("/product/%d", id)
 	product := &Product{}
-	err := s.client.Call("GET", url, nil, product)
+	err := s.client.call(ctx, "Product.Get", "GET", url, nil, product)
 	return product, err
 }
 
 // Update details of a Product on your integration
 // For more details see https://paystack.com/docs/api/product/#update
 func (s *ProductService) Update(id int, request *ProductRequest) (*Product, error) {
+	return s.UpdateContext(context.Background(), id, request)
+}
+
+// UpdateContext is like Update but uses ctx for the request.
+func (s *ProductService) UpdateContext(ctx context.Context, id int, request *ProductRequest) (*Product, error) {
 	url := fmt.Sprintf("product/%d", id)
 	product := &Product{}
-	err := s.client.Call("PUT", url, request, product)
+	err := s.client.call(ctx, "Product.Update", "PUT", url, request, product)
 	return product, err
 }
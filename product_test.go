@@ -1,16 +1,16 @@
 package paystack
 
 import (
-	"strconv"
 	"testing"
 )
 
 func TestProductCRUD(t *testing.T) {
+	useClient(t)
 
 	productRequest := &ProductRequest{
 		Name:        "Puff Puff",
 		Description: "Crispy flour ball with fluffy interior",
-		Price:       "5000",
+		Price:       NewMoney(5000, "NGN"),
 		Currency:    "NGN",
 		Unlimited:   false,
 		Quantity:    100,
@@ -54,7 +54,7 @@ func TestProductCRUD(t *testing.T) {
 	updateRequest := &ProductRequest{
 		Name:        "Puff Puff",
 		Description: "Crispy flour ball with fluffy interior",
-		Price:       "7000",
+		Price:       NewMoney(7000, "NGN"),
 		Currency:    "NGN",
 		Unlimited:   false,
 		Quantity:    170,
@@ -66,7 +66,7 @@ func TestProductCRUD(t *testing.T) {
 	if updatedProduct.Quantity != updateRequest.Quantity {
 		t.Errorf("Expected Product Quantity to be updated to %v, got %v", updatedProduct.Quantity, updateRequest.Quantity)
 	}
-	if strconv.Itoa(updatedProduct.Price) != updateRequest.Price {
+	if updatedProduct.Price != updateRequest.Price {
 		t.Errorf("Expected Product Quantity to be updated to %v, got %v", updatedProduct.Quantity, product.Quantity)
 	}
 }
@@ -0,0 +1,109 @@
+package paystack
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListParams are the pagination parameters shared by list filters.
+// Paystack pages are numbered from 1.
+type ListParams struct {
+	PerPage int `json:"perPage,omitempty"`
+	Page    int `json:"page,omitempty"`
+}
+
+// queryValues encodes the fields of the struct v as query parameters,
+// named after their json tags. Zero values are left out, and time.Time
+// and Timestamp values are encoded in ISO 8601 format.
+// Money is encoded in minor units.
+func queryValues(v interface{}) url.Values {
+	params := url.Values{}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return params
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Struct {
+		addQueryValues(params, rv)
+	}
+	return params
+}
+
+func addQueryValues(params url.Values, rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field, fv := rt.Field(i), rv.Field(i)
+		if field.Anonymous && fv.Kind() == reflect.Struct {
+			addQueryValues(params, fv)
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if s, ok := queryValue(fv); ok {
+			params.Set(name, s)
+		}
+	}
+}
+
+// queryValue formats a single field value, reporting false for zero values
+// and nil pointers. A pointer to a zero value is encoded, so that filters
+// such as active=false can be expressed.
+func queryValue(fv reflect.Value) (string, bool) {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return "", false
+		}
+		fv = fv.Elem()
+	} else if fv.IsZero() {
+		return "", false
+	}
+
+	switch t := fv.Interface().(type) {
+	case time.Time:
+		return t.UTC().Format(time.RFC3339), true
+	case Timestamp:
+		return t.UTC().Format(time.RFC3339), true
+	case Money:
+		return strconv.FormatInt(t.Amount, 10), true
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fv.Float(), 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// listURL returns the URL of a page of path, with filter encoded as query parameters
+func listURL(path string, count, offset int, filter interface{}) string {
+	params := queryValues(filter)
+	params.Set("perPage", strconv.Itoa(count))
+	params.Set("page", strconv.Itoa(offset))
+	return path + "?" + params.Encode()
+}
+
+// filterURL returns path with the fields of params encoded as query parameters
+func filterURL(path string, params interface{}) string {
+	q := queryValues(params).Encode()
+	if q == "" {
+		return path
+	}
+	return path + "?" + q
+}
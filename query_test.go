@@ -0,0 +1,112 @@
+package paystack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestQueryValues(t *testing.T) {
+	active := false
+	params := struct {
+		ListParams
+		Status   string    `json:"status,omitempty"`
+		Customer int       `json:"customer,omitempty"`
+		From     time.Time `json:"from,omitempty"`
+		To       time.Time `json:"to,omitempty"`
+		Active   *bool     `json:"active,omitempty"`
+		Ignored  string    `json:"-"`
+	}{
+		ListParams: ListParams{PerPage: 20},
+		Status:     "success",
+		From:       time.Date(2024, 1, 2, 13, 4, 5, 0, time.FixedZone("WAT", 3600)),
+		Active:     &active,
+		Ignored:    "x",
+	}
+
+	got := queryValues(&params).Encode()
+	want := "active=false&from=2024-01-02T12%3A04%3A05Z&perPage=20&status=success"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if q := queryValues((*TransactionListParams)(nil)); len(q) != 0 {
+		t.Errorf("Expected no values for a nil filter, got %v", q)
+	}
+}
+
+func TestListFiltersSentAsQuery(t *testing.T) {
+	type request struct {
+		path  string
+		query url.Values
+		body  string
+	}
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{r.URL.Path, r.URL.Query(), string(body)})
+		io.WriteString(w, `{"status":true,"message":"ok","data":[],"meta":{}}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient("sk_test_key", nil)
+	client.baseURL, _ = url.Parse(srv.URL)
+	client.LoggingEnabled = false
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := client.Transaction.ListWithParams(&TransactionListParams{
+		ListParams: ListParams{PerPage: 5, Page: 2},
+		Status:     "failed",
+		Customer:   42,
+		From:       from,
+		Currency:   "NGN",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Dispute.ListN(&DisputeFilterOptions{From: from, Status: "pending"}, 10, 1); err != nil {
+		t.Fatal(err)
+	}
+	active, inactive := true, false
+	if _, err := client.DedicatedVirtualAccount.ListN(&DVAListFilter{Active: &active, Currency: "NGN"}, 10, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DedicatedVirtualAccount.ListN(&DVAListFilter{Active: &inactive}, 10, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct{ path, query string }{
+		{"/transaction", "currency=NGN&customer=42&from=2024-03-01T00%3A00%3A00Z&page=2&perPage=5&status=failed"},
+		{"/dispute", "from=2024-03-01T00%3A00%3A00Z&page=1&perPage=10&status=pending"},
+		{"/dedicated_account", "active=true&currency=NGN&page=1&perPage=10"},
+		{"/dedicated_account", "active=false&page=1&perPage=10"},
+	}
+	for i, w := range want {
+		r := requests[i]
+		if r.path != w.path || r.query.Encode() != w.query {
+			t.Errorf("Expected %s?%s, got %s?%s", w.path, w.query, r.path, r.query.Encode())
+		}
+		if r.body != "" {
+			t.Errorf("Expected no request body for %s, got %q", r.path, r.body)
+		}
+	}
+}
+
+func TestIterWithParams(t *testing.T) {
+	var requests []int
+	client := newPagingClient(t, 5, &requests)
+
+	n := 0
+	it := client.Customer.IterWithParams(context.Background(), CustomerListParams{ListParams: ListParams{PerPage: 2, Page: 3}})
+	for it.Next() {
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 || len(requests) != 3 || requests[0] != 1 {
+		t.Errorf("Expected 5 customers from pages 1 to 3, got %d from %v", n, requests)
+	}
+}
@@ -0,0 +1,146 @@
+package paystack
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a client-side token-bucket limiter for Paystack API calls.
+// A single limiter is shared by all services on a Client. Endpoint groups,
+// identified by a path prefix such as "/bank/resolve", can be given a
+// tighter budget on top of the client-wide one.
+type RateLimiter struct {
+	mu     sync.Mutex
+	all    *bucket
+	groups []*groupBucket // sorted by descending prefix length
+}
+
+type groupBucket struct {
+	prefix string
+	*bucket
+}
+
+// NewRateLimiter returns a limiter allowing perSecond requests on average
+// with bursts of up to burst requests.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	return &RateLimiter{all: newBucket(perSecond, burst)}
+}
+
+// Group sets the budget for requests whose path starts with prefix.
+// Requests in a group draw from both the group and the client-wide budget.
+// It returns l so that groups can be chained onto NewRateLimiter.
+func (l *RateLimiter) Group(prefix string, perSecond float64, burst int) *RateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	prefix = "/" + strings.Trim(prefix, "/")
+	for _, g := range l.groups {
+		if g.prefix == prefix {
+			g.bucket = newBucket(perSecond, burst)
+			return l
+		}
+	}
+	l.groups = append(l.groups, &groupBucket{prefix: prefix, bucket: newBucket(perSecond, burst)})
+	sort.Slice(l.groups, func(i, j int) bool {
+		return len(l.groups[i].prefix) > len(l.groups[j].prefix)
+	})
+	return l
+}
+
+// Wait blocks until a request to path may proceed or ctx is done. A
+// request that is cancelled while waiting does not use up any budget.
+func (l *RateLimiter) Wait(ctx context.Context, path string) error {
+	g := l.group(path)
+	if g != nil {
+		if err := g.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if err := l.all.wait(ctx); err != nil {
+		if g != nil {
+			g.refund()
+		}
+		return err
+	}
+	return nil
+}
+
+// group returns the bucket with the longest prefix matching path, if any
+func (l *RateLimiter) group(path string) *bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, g := range l.groups {
+		if path == g.prefix || strings.HasPrefix(path, g.prefix+"/") {
+			return g.bucket
+		}
+	}
+	return nil
+}
+
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(perSecond float64, burst int) *bucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long
+// to wait before trying again
+func (b *bucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	if b.rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// refund returns a token taken by reserve
+func (b *bucket) refund() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+func (b *bucket) wait(ctx context.Context) error {
+	for {
+		d := b.reserve()
+		if d == 0 {
+			return nil
+		}
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := NewRateLimiter(1000, 2)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if d := l.all.reserve(); d != 0 {
+			t.Fatalf("Expected token %d to be available, wait %v", i, d)
+		}
+	}
+	if d := l.all.reserve(); d <= 0 {
+		t.Errorf("Expected empty bucket to require a wait")
+	}
+	if err := l.Wait(ctx, "/customer"); err != nil {
+		t.Errorf("Expected Wait to succeed once refilled, got %v", err)
+	}
+}
+
+func TestRateLimiterGroupBlocksUntilContextExpires(t *testing.T) {
+	l := NewRateLimiter(100, 10).Group("/bank/resolve", 0.001, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx, "/bank/resolve"); err != nil {
+		t.Fatalf("Expected first resolve to pass, got %v", err)
+	}
+	if err := l.Wait(ctx, "/bank/resolve_bvn/123"); err != nil {
+		t.Errorf("Expected /bank/resolve_bvn to be outside the group, got %v", err)
+	}
+	if err := l.Wait(ctx, "/bank/resolve"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRateLimiterRefundsGroupOnCancel(t *testing.T) {
+	l := NewRateLimiter(0.001, 1).Group("/bank/resolve", 0.001, 1)
+	l.all.reserve() // empty the client-wide budget
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "/bank/resolve"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+	if d := l.group("/bank/resolve").reserve(); d != 0 {
+		t.Errorf("Expected the group token to be refunded, wait %v", d)
+	}
+}
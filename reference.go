@@ -0,0 +1,138 @@
+package paystack
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReferenceGenerator returns a new unique reference for a transaction,
+// charge or transfer.
+type ReferenceGenerator func() (string, error)
+
+// Paystack accepts transaction references of letters, digits, '-', '.' and
+// '=', and transfer references of lowercase letters, digits, '-' and '_',
+// 16 to 50 characters long. Generated references keep to what both accept.
+const (
+	minReferenceLen = 16
+	maxReferenceLen = 50
+	ulidLen         = 26
+)
+
+// ULIDReferences returns a generator of references made of prefix, a dash
+// and a lowercase ULID, e.g. "order-01hq3v5x1m9kq2w8f3c0r6t7ay". ULIDs
+// carry 80 random bits and sort by creation time. The prefix may contain
+// lowercase letters, digits and dashes, and be up to 23 characters long.
+func ULIDReferences(prefix string) (ReferenceGenerator, error) {
+	if len(prefix)+1+ulidLen > maxReferenceLen {
+		return nil, fmt.Errorf("paystack: reference prefix %q is longer than %d characters", prefix, maxReferenceLen-ulidLen-1)
+	}
+	for _, r := range prefix {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+			return nil, fmt.Errorf("paystack: reference prefix %q may only contain lowercase letters, digits and '-'", prefix)
+		}
+	}
+	if prefix != "" {
+		prefix += "-"
+	}
+
+	g := &ulidGenerator{entropy: rand.Reader}
+	return func() (string, error) {
+		id, err := g.next(time.Now())
+		if err != nil {
+			return "", err
+		}
+		return prefix + id, nil
+	}, nil
+}
+
+// ValidReference reports whether ref may be used as both a transaction and
+// a transfer reference.
+func ValidReference(ref string) bool {
+	if len(ref) < minReferenceLen || len(ref) > maxReferenceLen {
+		return false
+	}
+	for _, r := range ref {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// crockford is the ULID alphabet, in lowercase as transfer references require
+const crockford = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// ulidGenerator creates ULIDs. IDs created within the same millisecond
+// increment the random part of the previous one, so that they stay unique
+// and ordered.
+type ulidGenerator struct {
+	mu      sync.Mutex
+	entropy io.Reader
+	lastMS  uint64
+	hi      uint16 // random bits 64-79
+	lo      uint64 // random bits 0-63
+}
+
+func (g *ulidGenerator) next(t time.Time) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(t.UnixMilli())
+	if ms <= g.lastMS && g.lastMS != 0 {
+		// same millisecond, or the clock went back: keep ordering
+		ms = g.lastMS
+		g.lo++
+		if g.lo == 0 {
+			g.hi++
+			if g.hi == 0 {
+				return "", fmt.Errorf("paystack: too many references created in one millisecond")
+			}
+		}
+	} else {
+		var b [10]byte
+		if _, err := io.ReadFull(g.entropy, b[:]); err != nil {
+			return "", fmt.Errorf("paystack: reading random bits for reference: %w", err)
+		}
+		g.hi = binary.BigEndian.Uint16(b[:2])
+		g.lo = binary.BigEndian.Uint64(b[2:])
+	}
+	g.lastMS = ms
+
+	// 48-bit timestamp and 80 random bits, encoded 5 bits at a time
+	var id [16]byte
+	binary.BigEndian.PutUint16(id[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+	binary.BigEndian.PutUint16(id[6:8], g.hi)
+	binary.BigEndian.PutUint64(id[8:16], g.lo)
+
+	var b strings.Builder
+	b.Grow(ulidLen)
+	// 128 bits in 26 characters: the first character holds the top 3 bits
+	for i := 0; i < ulidLen; i++ {
+		shift := uint(125 - 5*i)
+		b.WriteByte(crockford[bits(id[:], shift)])
+	}
+	return b.String(), nil
+}
+
+// bits returns the 5 bits of the big-endian 128-bit id starting at bit
+// shift, counting from the least significant bit. Bits above 127 are 0.
+func bits(id []byte, shift uint) byte {
+	var v byte
+	for j := uint(0); j < 5; j++ {
+		pos := shift + 4 - j
+		if pos > 127 {
+			continue
+		}
+		byteIdx := 15 - pos/8
+		if id[byteIdx]>>(pos%8)&1 == 1 {
+			v |= 1 << (4 - j)
+		}
+	}
+	return v
+}
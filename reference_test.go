@@ -0,0 +1,84 @@
+package paystack
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestULIDEncoding(t *testing.T) {
+	g := &ulidGenerator{entropy: bytes.NewReader(make([]byte, 10))}
+	id, err := g.next(time.UnixMilli(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "00000000000000000000000000" {
+		t.Errorf("Expected an all-zero ULID, got %s", id)
+	}
+
+	g = &ulidGenerator{entropy: bytes.NewReader(bytes.Repeat([]byte{0xff}, 10))}
+	id, _ = g.next(time.UnixMilli(1<<48 - 1))
+	if id != "7zzzzzzzzzzzzzzzzzzzzzzzzz" {
+		t.Errorf("Expected the largest ULID, got %s", id)
+	}
+
+	// known timestamp prefix from the ULID specification
+	g = &ulidGenerator{entropy: bytes.NewReader(make([]byte, 10))}
+	id, _ = g.next(time.UnixMilli(1469922850259))
+	if !strings.HasPrefix(id, "01arz3ndek") {
+		t.Errorf("Expected timestamp 01arz3ndek, got %s", id[:10])
+	}
+}
+
+func TestULIDReferences(t *testing.T) {
+	refs, err := ULIDReferences("order")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := map[string]bool{}
+	var all []string
+	for i := 0; i < 10000; i++ {
+		ref, err := refs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[ref] {
+			t.Fatalf("Duplicate reference %s", ref)
+		}
+		seen[ref] = true
+		all = append(all, ref)
+		if !strings.HasPrefix(ref, "order-") || !ValidReference(ref) {
+			t.Fatalf("Invalid reference %s", ref)
+		}
+	}
+	if !sort.StringsAreSorted(all) {
+		t.Errorf("Expected references to sort in creation order")
+	}
+
+	for _, prefix := range []string{"Order", "order_1", strings.Repeat("a", 24)} {
+		if _, err := ULIDReferences(prefix); err == nil {
+			t.Errorf("Expected prefix %q to be rejected", prefix)
+		}
+	}
+	if _, err := ULIDReferences(strings.Repeat("a", 23)); err != nil {
+		t.Errorf("Expected a 23 character prefix to be accepted, got %v", err)
+	}
+}
+
+func TestValidReference(t *testing.T) {
+	tests := map[string]bool{
+		"order-01hq3v5x1m9kq2w8f3c0r6t7ay": true,
+		"short":                            false,
+		"Order-01HQ3V5X1M9KQ2W8F3C0R6T7AY": false,
+		"order_01hq3v5x1m9kq2w8f3c0r6t7ay": false,
+		strings.Repeat("a", 51):            false,
+	}
+	for ref, want := range tests {
+		if ValidReference(ref) != want {
+			t.Errorf("Expected ValidReference(%q) to be %v", ref, want)
+		}
+	}
+}
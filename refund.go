@@ -1,71 +1,137 @@
 package paystack
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 type RefundService service
 
 type Refund struct {
 	Transaction    Transaction `json:"transaction,omitempty"`
 	Integration    int         `json:"integration,omitempty"`
-	DeductedAmount int         `json:"deducted_amount,omitempty"`
+	DeductedAmount Money       `json:"deducted_amount,omitzero"`
 	Channel        interface{} `json:"channel,omitempty"` // TODO: Confirm data type
 	MerchantNote   string      `json:"merchant_note,omitempty"`
 	CustomerNote   string      `json:"customer_note,omitempty"`
 	Status         string      `json:"status,omitempty"`
 	RefundedBy     string      `json:"refunded_by,omitempty"`
-	ExpectedAt     string      `json:"expected_at,omitempty"`
+	ExpectedAt     Timestamp   `json:"expected_at,omitzero"`
 	Currency       string      `json:"currency,omitempty"`
 	Domain         string      `json:"domain,omitempty"`
-	Amount         int         `json:"amount,omitempty"`
+	Amount         Money       `json:"amount,omitzero"`
 	FullyDeducted  bool        `json:"fully_deducted,omitempty"`
 	Id             int         `json:"id,omitempty"`
-	CreatedAt      string      `json:"createdAt,omitempty"`
-	UpdatedAt      string      `json:"updatedAt,omitempty"`
+	CreatedAt      Timestamp   `json:"createdAt,omitzero"`
+	UpdatedAt      Timestamp   `json:"updatedAt,omitzero"`
+}
+
+// UnmarshalJSON decodes r, accepting camelCase or snake_case timestamp keys.
+func (r *Refund) UnmarshalJSON(data []byte) error {
+	type refund Refund
+	return unmarshalTimestamps(data, (*refund)(r))
 }
 
 type RefundRequest struct {
 	Transaction  string `json:"transaction,omitempty"`   // Transaction reference or id
-	Amount       int    `json:"amount,omitempty"`        // Optional: Defaults to original transaction amount
+	Amount       Money  `json:"amount,omitzero"`         // Optional: Defaults to original transaction amount
 	Currency     string `json:"currency,omitempty"`      // Optional
 	CustomerNote string `json:"customer_note,omitempty"` // Optional
 	MerchantNote string `json:"merchant_note,omitempty"` // Optional
 }
 
 // RefundList is a list object for Splits.
-type RefundList struct {
-	Meta   ListMeta
-	Values []Refund `json:"data"`
+type RefundList = List[Refund]
+
+// RefundListParams filters a list of refunds. All fields are optional.
+type RefundListParams struct {
+	ListParams
+	Transaction string    `json:"transaction,omitempty"` // Transaction ID or reference
+	Currency    string    `json:"currency,omitempty"`
+	From        time.Time `json:"from,omitempty"`
+	To          time.Time `json:"to,omitempty"`
 }
 
 // Create and manage transaction refunds.
 // For more details see https://paystack.com/docs/api/refund/#refunds
 func (s *RefundService) CreateRefund(request *RefundRequest) (*Refund, error) {
+	return s.CreateRefundContext(context.Background(), request)
+}
+
+// CreateRefundContext is like CreateRefund but uses ctx for the request.
+func (s *RefundService) CreateRefundContext(ctx context.Context, request *RefundRequest) (*Refund, error) {
 	url := "/refund"
 	refund := &Refund{}
-	err := s.client.Call("POST", url, request, refund)
+	err := s.client.call(ctx, "Refund.CreateRefund", "POST", url, request, refund)
 	return refund, err
 }
 
 // List refunds available on your integration
 // For more details see https://paystack.com/docs/api/refund/#list
 func (s *RefundService) List() (*RefundList, error) {
-	return s.ListN(10, 1)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *RefundService) ListContext(ctx context.Context) (*RefundList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // List refunds available on your integration
 // For more details see https://paystack.com/docs/api/refund/#list
 func (s *RefundService) ListN(count, offset int) (*RefundList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *RefundService) ListNContext(ctx context.Context, count, offset int) (*RefundList, error) {
 	url := paginateURL("/refund", count, offset)
 	refunds := &RefundList{}
-	err := s.client.Call("GET", url, nil, refunds)
+	err := s.client.call(ctx, "Refund.ListN", "GET", url, nil, refunds)
+	return refunds, err
+}
+
+// Iter returns an iterator over all refunds, fetching perPage of them at a time.
+func (s *RefundService) Iter(ctx context.Context, perPage int) *Iterator[Refund] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Refund], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
+
+// ListWithParams returns a list of refunds matching params
+// For more details see https://paystack.com/docs/api/refund/#list
+func (s *RefundService) ListWithParams(params *RefundListParams) (*RefundList, error) {
+	return s.ListWithParamsContext(context.Background(), params)
+}
+
+// ListWithParamsContext is like ListWithParams but uses ctx for the request.
+func (s *RefundService) ListWithParamsContext(ctx context.Context, params *RefundListParams) (*RefundList, error) {
+	u := filterURL("/refund", params)
+	refunds := &RefundList{}
+	err := s.client.call(ctx, "Refund.ListWithParams", "GET", u, nil, refunds)
 	return refunds, err
 }
 
+// IterWithParams returns an iterator over all refunds matching params,
+// fetching params.PerPage of them at a time. params.Page is ignored.
+func (s *RefundService) IterWithParams(ctx context.Context, params RefundListParams) *Iterator[Refund] {
+	return newIterator(ctx, params.PerPage, func(ctx context.Context, perPage, page int) (*List[Refund], error) {
+		params.ListParams = ListParams{PerPage: perPage, Page: page}
+		return s.ListWithParamsContext(ctx, &params)
+	})
+}
+
 // Get details of a refund on your integration
 // For more details see https://paystack.com/docs/api/refund/#fetch
 func (s *RefundService) Get(id int) (*Refund, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *RefundService) GetContext(ctx context.Context, id int) (*Refund, error) {
 	url := fmt.Sprintf("/refund/%d", id)
 	refund := &Refund{}
-	err := s.client.Call("GET", url, nil, refund)
+	err := s.client.call(ctx, "Refund.Get", "GET", url, nil, refund)
 	return refund, err
 }
@@ -1,26 +1,33 @@
 package paystack
 
 import (
-	"fmt"
 	"testing"
 )
 
 func TestRefund(t *testing.T) {
-	txn := &TransactionRequest{
-		Email:     "user123@gmail.com",
-		Amount:    600000,
-		Reference: "Txn-" + fmt.Sprintf("%d", makeTimestamp()),
-	}
-	resp, err := c.Transaction.Initialize(txn)
+	useClient(t)
+
+	// refunds need a successful transaction: charge a test card that
+	// needs no validation
+	charge, err := c.Charge.Create(&ChargeRequest{
+		Email:  "user123@gmail.com",
+		Amount: NewMoney(6000, "NGN"),
+		Card: &Card{
+			Number:       "4084084084084081",
+			CVV:          "408",
+			ExpirtyMonth: "12",
+			ExpiryYear:   "2030",
+		},
+	})
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 
-	if resp["reference"] == "" {
-		t.Error("Missing transaction reference")
+	if charge["status"] != "success" {
+		t.Errorf("Expected a successful charge, got %v", charge["status"])
 	}
 
-	txn1, err := c.Transaction.Verify(resp["reference"].(string))
+	txn1, err := c.Transaction.Verify(charge["reference"].(string))
 
 	if err != nil {
 		t.Error(err)
@@ -43,7 +50,7 @@ func TestRefund(t *testing.T) {
 		t.Errorf("Expected Refund ID to be set")
 	}
 
-	if refund.Amount != int(txn1.Amount) {
+	if refund.Amount != txn1.Amount {
 		t.Errorf("Expected refund amount to be %v, got %v", txn1.Amount, refund.Amount)
 	}
 
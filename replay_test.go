@@ -0,0 +1,6 @@
+//go:build !live
+
+package paystack
+
+// liveSuite is false in the replay suite, whose tests replay cassettes
+const liveSuite = false
@@ -0,0 +1,96 @@
+package paystack
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseInfo is the HTTP metadata of a Paystack API call, useful when
+// raising support tickets with Paystack.
+type ResponseInfo struct {
+	StatusCode int
+	Header     http.Header
+
+	// RequestID identifies the request on Paystack's side, when the
+	// response carries one.
+	RequestID string
+
+	RateLimit RateLimit
+
+	// Latency is the duration of the whole call, including retries.
+	Latency  time.Duration
+	Attempts int
+
+	// Message is the message of the response envelope.
+	Message string
+
+	// Body is the raw JSON response body.
+	Body []byte
+
+	// Cached is true if the response was served from the client's Cache,
+	// in which case Header is empty and Attempts is zero.
+	Cached bool
+}
+
+// RateLimit is the rate-limit state reported in the response headers.
+// Fields are zero when the headers are absent.
+type RateLimit struct {
+	Limit      int
+	Remaining  int
+	Reset      time.Time
+	RetryAfter time.Duration
+}
+
+type responseInfoKey struct{}
+
+// WithResponseInfo returns a copy of ctx that captures the response
+// metadata of a call made with it into info:
+//
+//	var info paystack.ResponseInfo
+//	transfer, err := client.Transfer.InitiateContext(paystack.WithResponseInfo(ctx, &info), req)
+//	log.Println(info.StatusCode, info.RequestID, info.Message)
+//
+// info is left untouched if no response was received.
+func WithResponseInfo(ctx context.Context, info *ResponseInfo) context.Context {
+	return context.WithValue(ctx, responseInfoKey{}, info)
+}
+
+// requestIDHeaders are the headers that may identify a request, in order of preference
+var requestIDHeaders = []string{"X-Request-Id", "X-Paystack-Request-Id", "Cf-Ray"}
+
+func newResponseInfo(resp *http.Response, body []byte, message string, latency time.Duration, attempts int) *ResponseInfo {
+	info := &ResponseInfo{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		RateLimit:  parseRateLimit(resp.Header),
+		Latency:    latency,
+		Attempts:   attempts,
+		Message:    message,
+		Body:       body,
+	}
+	for _, h := range requestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			info.RequestID = id
+			break
+		}
+	}
+	return info
+}
+
+func parseRateLimit(h http.Header) RateLimit {
+	var rl RateLimit
+	rl.Limit, _ = strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	rl.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		// the reset is either a Unix timestamp or a number of seconds from now
+		if reset > 1e9 {
+			rl.Reset = time.Unix(reset, 0)
+		} else {
+			rl.Reset = time.Now().Add(time.Duration(reset) * time.Second)
+		}
+	}
+	rl.RetryAfter, _ = parseRetryAfter(h.Get("Retry-After"))
+	return rl
+}
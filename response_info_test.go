@@ -0,0 +1,54 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestWithResponseInfo(t *testing.T) {
+	const body = `{"status":true,"message":"Transfer has been queued","data":{"transfer_code":"TRF_1"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_123")
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		if r.URL.Path == "/transfer/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":false,"message":"Transfer not found"}`))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	client := NewClient("sk_test_key", nil)
+	client.baseURL, _ = url.Parse(srv.URL)
+	client.LoggingEnabled = false
+
+	var info ResponseInfo
+	ctx := WithResponseInfo(context.Background(), &info)
+	if _, err := client.Transfer.InitiateContext(ctx, &TransferRequest{Amount: NewMoney(100, "NGN")}); err != nil {
+		t.Fatal(err)
+	}
+	if info.StatusCode != 200 || info.RequestID != "req_123" || info.Attempts != 1 {
+		t.Errorf("Expected status, request ID and attempts to be captured, got %+v", info)
+	}
+	if info.Message != "Transfer has been queued" || string(info.Body) != body {
+		t.Errorf("Expected envelope message and raw body, got %q, %s", info.Message, info.Body)
+	}
+	if info.RateLimit.Limit != 100 || info.RateLimit.Remaining != 99 {
+		t.Errorf("Expected rate limit headers to be parsed, got %+v", info.RateLimit)
+	}
+	if info.Latency <= 0 {
+		t.Errorf("Expected latency to be recorded")
+	}
+
+	info = ResponseInfo{}
+	_, err := client.Transfer.GetContext(ctx, "missing")
+	if !errors.Is(err, ErrNotFound) || info.StatusCode != 404 || info.Message != "Transfer not found" {
+		t.Errorf("Expected response info on error, got %+v, %v", info, err)
+	}
+}
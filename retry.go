@@ -0,0 +1,145 @@
+package paystack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of requests that fail with
+// HTTP 429, a 5xx status or a transient network error.
+//
+// GET requests are always safe to retry. Other requests are only retried
+// when RetryReferenced is set and the request body carries a caller-supplied
+// reference, which Paystack uses to deduplicate charges and transfers.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+
+	// BaseDelay is the backoff before the first retry. It doubles with every
+	// attempt, up to MaxDelay, and a random jitter is applied on top.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// RetryReferenced allows retrying non-GET requests that carry a reference.
+	RetryReferenced bool
+}
+
+// DefaultRetryPolicy returns the recommended retry policy: three attempts
+// with exponential backoff, retrying POSTs only when they carry a reference.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     3,
+		BaseDelay:       500 * time.Millisecond,
+		MaxDelay:        10 * time.Second,
+		RetryReferenced: true,
+	}
+}
+
+// allows reports whether a request with the given method and JSON payload
+// may be retried under the policy
+func (p *RetryPolicy) allows(method string, payload []byte) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	return p.RetryReferenced && hasReference(payload)
+}
+
+// backoff returns the delay before the next attempt. A Retry-After header
+// on resp takes precedence over the computed exponential backoff, but is
+// also capped at MaxDelay.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxDelay > 0 && d > p.MaxDelay {
+				d = p.MaxDelay
+			}
+			return d
+		}
+	}
+
+	d := p.BaseDelay << uint(attempt-1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	// full jitter spreads out retries from clients that failed together
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// wait sleeps for the backoff of the given attempt, or until ctx is done
+func (p *RetryPolicy) wait(ctx context.Context, attempt int, resp *http.Response) error {
+	t := time.NewTimer(p.backoff(attempt, resp))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// hasReference reports whether a JSON request body has a non-empty reference
+func hasReference(payload []byte) bool {
+	var body struct {
+		Reference interface{} `json:"reference"`
+	}
+	if len(payload) == 0 || json.Unmarshal(payload, &body) != nil {
+		return false
+	}
+	switch ref := body.Reference.(type) {
+	case string:
+		return ref != ""
+	case []interface{}: // url.Values bodies
+		return len(ref) > 0 && ref[0] != ""
+	}
+	return false
+}
+
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// isRetryableError reports whether a transport error is transient.
+// Errors caused by ctx being done are never retried.
+func isRetryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
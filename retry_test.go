@@ -0,0 +1,96 @@
+package paystack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	client := NewClient("sk_test_key", nil)
+	client.baseURL, _ = url.Parse(srv.URL)
+	client.LoggingEnabled = false
+	client.Retry = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, RetryReferenced: true}
+	return client
+}
+
+func TestRetryGETOnServerError(t *testing.T) {
+	var calls int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":true,"message":"ok","data":{"reference":"ref"}}`))
+	})
+
+	txn, err := client.Transaction.Verify("ref")
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if txn.Reference != "ref" {
+		t.Errorf("Expected reference %q, got %q", "ref", txn.Reference)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryPOSTRequiresReference(t *testing.T) {
+	var calls int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client.Transfer.Initiate(&TransferRequest{Amount: NewMoney(100, "NGN"), Recipient: "RCP_1"})
+	if calls != 1 {
+		t.Errorf("Expected POST without reference to be attempted once, got %d", calls)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	client.Transfer.Initiate(&TransferRequest{Amount: NewMoney(100, "NGN"), Recipient: "RCP_1", Reference: "trf-ref-1"})
+	if calls != 3 {
+		t.Errorf("Expected a transfer with a reference to be attempted 3 times, got %d", calls)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	client.Transaction.ChargeAuthorization(&TransactionRequest{Amount: NewMoney(100, "NGN"), Reference: "ref-1"})
+	if calls != 3 {
+		t.Errorf("Expected POST with reference to be attempted 3 times, got %d", calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("2"); !ok || d != 2*time.Second {
+		t.Errorf("Expected 2s, got %v", d)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Errorf("Expected invalid Retry-After to be ignored")
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date); !ok || d <= 0 {
+		t.Errorf("Expected positive delay for HTTP date, got %v", d)
+	}
+}
+
+func TestRetryAfterCappedAtMaxDelay(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}
+	for _, v := range []string{"3600", time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat)} {
+		resp := &http.Response{Header: http.Header{"Retry-After": {v}}}
+		if d := p.backoff(1, resp); d != p.MaxDelay {
+			t.Errorf("Retry-After %s: expected the delay to be capped at %v, got %v", v, p.MaxDelay, d)
+		}
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": {"2"}}}
+	if d := p.backoff(1, resp); d != 2*time.Second {
+		t.Errorf("Expected a Retry-After under MaxDelay to be kept, got %v", d)
+	}
+}
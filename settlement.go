@@ -1,26 +1,42 @@
 package paystack
 
+import "context"
+
 // SettlementService handles operations related to the settlement
 // For more details see https://developers.paystack.co/v1.0/reference#create-settlement
 type SettlementService service
 
 // SettlementList is a list object for settlements.
-type SettlementList struct {
-	Meta   ListMeta
-	Values []Response `json:"data,omitempty"`
-}
+type SettlementList = List[Response]
 
 // List returns a list of settlements.
 // For more details see https://developers.paystack.co/v1.0/reference#settlements
 func (s *SettlementService) List() (*SettlementList, error) {
-	return s.ListN(10, 0)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *SettlementService) ListContext(ctx context.Context) (*SettlementList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // ListN returns a list of settlements
 // For more details see https://developers.paystack.co/v1.0/reference#settlements
 func (s *SettlementService) ListN(count, offset int) (*SettlementList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *SettlementService) ListNContext(ctx context.Context, count, offset int) (*SettlementList, error) {
 	u := paginateURL("/settlement", count, offset)
 	pg := &SettlementList{}
-	err := s.client.Call("GET", u, nil, pg)
+	err := s.client.call(ctx, "Settlement.ListN", "GET", u, nil, pg)
 	return pg, err
 }
+
+// Iter returns an iterator over all settlements, fetching perPage of them at a time.
+func (s *SettlementService) Iter(ctx context.Context, perPage int) *Iterator[Response] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Response], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
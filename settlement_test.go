@@ -6,6 +6,8 @@ import (
 )
 
 func TestSettlementList(t *testing.T) {
+	useClient(t)
+
 	// retrieve the settlement list
 	settlements, err := c.Settlement.List()
 
@@ -1,6 +1,9 @@
 package paystack
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // SplitService handles operations related to transaction Splits
 // For more details see https://paystack.com/docs/api/split/
@@ -17,13 +20,19 @@ type Split struct {
 	SplitCode        string               `json:"split_code,omitempty"`
 	Active           bool                 `json:"active,omitempty"`
 	BearerType       string               `json:"bearer_type,omitempty"`
-	CreatedAt        string               `json:"created_at,omitempty"`
-	UpdatedAt        string               `json:"updated_at,omitempty"`
+	CreatedAt        Timestamp            `json:"created_at,omitzero"`
+	UpdatedAt        Timestamp            `json:"updated_at,omitzero"`
 	IsDynamic        bool                 `json:"is_dynamic,omitempty"`
 	Subaccounts      []BeneficiaryAccount `json:"subaccounts,omitempty"`
 	TotalSubAccounts int                  `json:"total_subaccounts,omitempty"`
 }
 
+// UnmarshalJSON decodes s, accepting camelCase or snake_case timestamp keys.
+func (s *Split) UnmarshalJSON(data []byte) error {
+	type split Split
+	return unmarshalTimestamps(data, (*split)(s))
+}
+
 // SplitRequest represents a request to create a transaction Split
 type SplitRequest struct {
 	Name             string                      `json:"name,omitempty"`
@@ -35,10 +44,7 @@ type SplitRequest struct {
 }
 
 // SplitList is a list object for Splits.
-type SplitList struct {
-	Meta   ListMeta
-	Values []Split `json:"data"`
-}
+type SplitList = List[Split]
 
 // Represents a request to update a split
 type SplitUpdateRequest struct {
@@ -57,72 +63,114 @@ type BeneficiaryAccount struct {
 // Represents a SubAccount code paired with its allocated share of the split. Used in requests to create Splits.
 type BeneficiaryAccountRequest struct {
 	SubAccountCode string `json:"subaccount,omitempty"`
-	Share          int `json:"share,omitempty"`
+	Share          int    `json:"share,omitempty"`
 }
 
 // Create a split payment on your integration
 // For more details see https://paystack.com/docs/api/split/#create
 func (s *SplitService) CreateSplit(request *SplitRequest) (*Split, error) {
+	return s.CreateSplitContext(context.Background(), request)
+}
+
+// CreateSplitContext is like CreateSplit but uses ctx for the request.
+func (s *SplitService) CreateSplitContext(ctx context.Context, request *SplitRequest) (*Split, error) {
 	url := "/split"
 	response := &Split{}
-	err := s.client.Call("POST", url, request, response)
+	err := s.client.call(ctx, "Split.CreateSplit", "POST", url, request, response)
 	return response, err
 }
 
 // List available transaction Splits
 // For more details see https://paystack.com/docs/api/split/#list
 func (s *SplitService) List() (*SplitList, error) {
-	return s.ListN(10, 1)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *SplitService) ListContext(ctx context.Context) (*SplitList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // List available transaction Splits
 // For more details see https://paystack.com/docs/api/split/#list
 func (s *SplitService) ListN(count, offset int) (*SplitList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *SplitService) ListNContext(ctx context.Context, count, offset int) (*SplitList, error) {
 	url := paginateURL("/split", count, offset)
 	splits := &SplitList{}
-	err := s.client.Call("GET", url, nil, splits)
+	err := s.client.call(ctx, "Split.ListN", "GET", url, nil, splits)
 	return splits, err
 }
 
+// Iter returns an iterator over all transaction splits, fetching perPage of them at a time.
+func (s *SplitService) Iter(ctx context.Context, perPage int) *Iterator[Split] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Split], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
+
 // Get details of Split with the specified id
 // For more details see https://paystack.com/docs/api/split/#fetch
 func (s *SplitService) Get(id int) (*Split, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *SplitService) GetContext(ctx context.Context, id int) (*Split, error) {
 	url := fmt.Sprintf("/split/%d", id)
 	split := &Split{}
-	err := s.client.Call("GET", url, nil, split)
+	err := s.client.call(ctx, "Split.Get", "GET", url, nil, split)
 	return split, err
 }
 
 // Update a transaction split details on your integration
 // For more details see https://paystack.com/docs/api/split/#update
 func (s *SplitService) Update(id int, request *SplitUpdateRequest) (*Split, error) {
+	return s.UpdateContext(context.Background(), id, request)
+}
+
+// UpdateContext is like Update but uses ctx for the request.
+func (s *SplitService) UpdateContext(ctx context.Context, id int, request *SplitUpdateRequest) (*Split, error) {
 	url := fmt.Sprintf("split/%d", id)
 	split := &Split{}
-	err := s.client.Call("PUT", url, request, split)
+	err := s.client.call(ctx, "Split.Update", "PUT", url, request, split)
 	return split, err
 }
 
 // Add a Subaccount to a Transaction Split, or update the share of an existing Subaccount in a Transaction Split
 // For more details see https://paystack.com/docs/api/split/#add-subaccount
 func (s *SplitService) UpdateSubAccounts(splitID int, subAccountCode string, share int) (*Split, error) {
+	return s.UpdateSubAccountsContext(context.Background(), splitID, subAccountCode, share)
+}
+
+// UpdateSubAccountsContext is like UpdateSubAccounts but uses ctx for the request.
+func (s *SplitService) UpdateSubAccountsContext(ctx context.Context, splitID int, subAccountCode string, share int) (*Split, error) {
 	url := fmt.Sprintf("split/%d/subaccount/add", splitID)
 	split := &Split{}
 	requestData := map[string]interface{}{
 		"subaccount": subAccountCode,
 		"share":      share,
 	}
-	err := s.client.Call("POST", url, requestData, split)
+	err := s.client.call(ctx, "Split.UpdateSubAccounts", "POST", url, requestData, split)
 	return split, err
 }
 
 // Remove a subaccount from a transaction split
 // For more details see https://paystack.com/docs/api/split/#remove-subaccount
 func (s *SplitService) RemoveSubAccount(splitID int, subAccountCode string) error {
+	return s.RemoveSubAccountContext(context.Background(), splitID, subAccountCode)
+}
+
+// RemoveSubAccountContext is like RemoveSubAccount but uses ctx for the request.
+func (s *SplitService) RemoveSubAccountContext(ctx context.Context, splitID int, subAccountCode string) error {
 	url := fmt.Sprintf("split/%d/subaccount/remove", splitID)
 	split := &Split{}
 	requestData := map[string]string{
 		"subaccount": subAccountCode,
 	}
-	err := s.client.Call("POST", url, requestData, split)
+	err := s.client.call(ctx, "Split.RemoveSubAccount", "POST", url, requestData, split)
 	return err
 }
@@ -3,6 +3,8 @@ package paystack
 import "testing"
 
 func TestSplitCRUD(t *testing.T) {
+	useClient(t)
+
 
 	subAccount := &SubAccount{
 		BusinessName:     "Sunshine Studios",
@@ -1,6 +1,9 @@
 package paystack
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // SubAccountService handles operations related to sub accounts
 // For more details see https://developers.paystack.co/v1.0/reference#create-subaccount
@@ -9,39 +12,47 @@ type SubAccountService service
 // SubAccount is the resource representing your Paystack subaccount.
 // For more details see https://developers.paystack.co/v1.0/reference#create-subaccount
 type SubAccount struct {
-	ID                  int      `json:"id,omitempty"`
-	CreatedAt           string   `json:"createdAt,omitempty"`
-	UpdatedAt           string   `json:"updatedAt,omitempty"`
-	Domain              string   `json:"domain,omitempty"`
-	Integration         int      `json:"integration,omitempty"`
-	BusinessName        string   `json:"business_name,omitempty"`
-	SubAccountCode      string   `json:"subaccount_code,omitempty"`
-	Description         string   `json:"description,omitempty"`
-	PrimaryContactName  string   `json:"primary_contact_name,omitempty"`
-	PrimaryContactEmail string   `json:"primary_contact_email,omitempty"`
-	PrimaryContactPhone string   `json:"primary_contact_phone,omitempty"`
-	Metadata            Metadata `json:"metadata,omitempty"`
-	PercentageCharge    float32  `json:"percentage_charge,omitempty"`
-	IsVerified          bool     `json:"is_verified,omitempty"`
-	SettlementBank      string   `json:"settlement_bank,omitempty"`
-	AccountNumber       string   `json:"account_number,omitempty"`
-	SettlementSchedule  string   `json:"settlement_schedule,omitempty"`
-	Active              bool     `json:"active,omitempty"`
-	Migrate             bool     `json:"migrate,omitempty"`
+	ID                  int       `json:"id,omitempty"`
+	CreatedAt           Timestamp `json:"createdAt,omitzero"`
+	UpdatedAt           Timestamp `json:"updatedAt,omitzero"`
+	Domain              string    `json:"domain,omitempty"`
+	Integration         int       `json:"integration,omitempty"`
+	BusinessName        string    `json:"business_name,omitempty"`
+	SubAccountCode      string    `json:"subaccount_code,omitempty"`
+	Description         string    `json:"description,omitempty"`
+	PrimaryContactName  string    `json:"primary_contact_name,omitempty"`
+	PrimaryContactEmail string    `json:"primary_contact_email,omitempty"`
+	PrimaryContactPhone string    `json:"primary_contact_phone,omitempty"`
+	Metadata            Metadata  `json:"metadata,omitempty"`
+	PercentageCharge    float32   `json:"percentage_charge,omitempty"`
+	IsVerified          bool      `json:"is_verified,omitempty"`
+	SettlementBank      string    `json:"settlement_bank,omitempty"`
+	AccountNumber       string    `json:"account_number,omitempty"`
+	SettlementSchedule  string    `json:"settlement_schedule,omitempty"`
+	Active              bool      `json:"active,omitempty"`
+	Migrate             bool      `json:"migrate,omitempty"`
 }
 
-// SubAccountList is a list object for subaccounts.
-type SubAccountList struct {
-	Meta   ListMeta
-	Values []SubAccount `json:"data"`
+// UnmarshalJSON decodes a, accepting camelCase or snake_case timestamp keys.
+func (a *SubAccount) UnmarshalJSON(data []byte) error {
+	type subAccount SubAccount
+	return unmarshalTimestamps(data, (*subAccount)(a))
 }
 
+// SubAccountList is a list object for subaccounts.
+type SubAccountList = List[SubAccount]
+
 // Create creates a new subaccount
 // For more details see https://paystack.com/docs/api/#subaccount-create
 func (s *SubAccountService) Create(subaccount *SubAccount) (*SubAccount, error) {
+	return s.CreateContext(context.Background(), subaccount)
+}
+
+// CreateContext is like Create but uses ctx for the request.
+func (s *SubAccountService) CreateContext(ctx context.Context, subaccount *SubAccount) (*SubAccount, error) {
 	u := fmt.Sprintf("/subaccount")
 	acc := &SubAccount{}
-	err := s.client.Call("POST", u, subaccount, acc)
+	err := s.client.call(ctx, "SubAccount.Create", "POST", u, subaccount, acc)
 	return acc, err
 }
 
@@ -49,9 +60,14 @@ func (s *SubAccountService) Create(subaccount *SubAccount) (*SubAccount, error)
 // For more details see https://developers.paystack.co/v1.0/reference#update-subaccount
 // TODO: use ID or slug
 func (s *SubAccountService) Update(subaccount *SubAccount) (*SubAccount, error) {
+	return s.UpdateContext(context.Background(), subaccount)
+}
+
+// UpdateContext is like Update but uses ctx for the request.
+func (s *SubAccountService) UpdateContext(ctx context.Context, subaccount *SubAccount) (*SubAccount, error) {
 	u := fmt.Sprintf("subaccount/%d", subaccount.ID)
 	acc := &SubAccount{}
-	err := s.client.Call("PUT", u, subaccount, acc)
+	err := s.client.call(ctx, "SubAccount.Update", "PUT", u, subaccount, acc)
 
 	return acc, err
 }
@@ -60,9 +76,14 @@ func (s *SubAccountService) Update(subaccount *SubAccount) (*SubAccount, error)
 // For more details see https://developers.paystack.co/v1.0/reference#fetch-subaccount
 // TODO: use ID or slug
 func (s *SubAccountService) Get(id int) (*SubAccount, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *SubAccountService) GetContext(ctx context.Context, id int) (*SubAccount, error) {
 	u := fmt.Sprintf("/subaccount/%d", id)
 	acc := &SubAccount{}
-	err := s.client.Call("GET", u, nil, acc)
+	err := s.client.call(ctx, "SubAccount.Get", "GET", u, nil, acc)
 
 	return acc, err
 }
@@ -70,14 +91,31 @@ func (s *SubAccountService) Get(id int) (*SubAccount, error) {
 // List returns a list of subaccounts.
 // For more details see https://developers.paystack.co/v1.0/reference#list-subaccounts
 func (s *SubAccountService) List() (*SubAccountList, error) {
-	return s.ListN(10, 1)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *SubAccountService) ListContext(ctx context.Context) (*SubAccountList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // ListN returns a list of subaccounts
 // For more details see https://paystack.com/docs/api/#subaccount-list
 func (s *SubAccountService) ListN(count, offset int) (*SubAccountList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *SubAccountService) ListNContext(ctx context.Context, count, offset int) (*SubAccountList, error) {
 	u := paginateURL("/subaccount", count, offset)
 	acc := &SubAccountList{}
-	err := s.client.Call("GET", u, nil, acc)
+	err := s.client.call(ctx, "SubAccount.ListN", "GET", u, nil, acc)
 	return acc, err
 }
+
+// Iter returns an iterator over all subaccounts, fetching perPage of them at a time.
+func (s *SubAccountService) Iter(ctx context.Context, perPage int) *Iterator[SubAccount] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[SubAccount], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
@@ -3,6 +3,8 @@ package paystack
 import "testing"
 
 func TestSubAccountCRUD(t *testing.T) {
+	useClient(t)
+
 	subAccount1 := &SubAccount{
 		BusinessName:     "Sunshine Studios",
 		SettlementBank:   "044",
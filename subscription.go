@@ -1,6 +1,7 @@
 package paystack
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -12,105 +13,181 @@ type SubscriptionService service
 // Subscription represents a Paystack subscription
 // For more details see https://developers.paystack.co/v1.0/reference#create-subscription
 type Subscription struct {
-	ID          int    `json:"id,omitempty"`
-	CreatedAt   string `json:"createdAt,omitempty"`
-	UpdatedAt   string `json:"updatedAt,omitempty"`
-	Domain      string `json:"domain,omitempty"`
-	Integration int    `json:"integration,omitempty"`
+	ID          int       `json:"id,omitempty"`
+	CreatedAt   Timestamp `json:"createdAt,omitzero"`
+	UpdatedAt   Timestamp `json:"updatedAt,omitzero"`
+	Domain      string    `json:"domain,omitempty"`
+	Integration int       `json:"integration,omitempty"`
 	// inconsistent API response. Create returns Customer code, Fetch returns an object
-	Customer  interface{} `json:"customer,omitempty"`
-	Plan      string      `json:"plan,omitempty"`
-	StartDate string      `json:"start,omitempty"`
+	Customer interface{} `json:"customer,omitempty"`
+	// inconsistent API response. Create returns Plan ID, Fetch returns an object
+	Plan      interface{} `json:"plan,omitempty"`
+	StartDate Timestamp   `json:"start,omitzero"`
 	// inconsistent API response. Fetch returns string, List returns an object
 	Authorization    interface{}   `json:"authorization,omitempty"`
 	Invoices         []interface{} `json:"invoices,omitempty"`
 	Status           string        `json:"status,omitempty"`
 	Quantity         int           `json:"quantity,omitempty"`
-	Amount           int           `json:"amount,omitempty"`
+	Amount           Money         `json:"amount,omitzero"`
 	SubscriptionCode string        `json:"subscription_code,omitempty"`
 	EmailToken       string        `json:"email_token,omitempty"`
 	EasyCronID       string        `json:"easy_cron_id,omitempty"`
 	CronExpression   string        `json:"cron_expression,omitempty"`
-	NextPaymentDate  string        `json:"next_payment_date,omitempty"`
+	NextPaymentDate  Timestamp     `json:"next_payment_date,omitzero"`
 	OpenInvoice      string        `json:"open_invoice,omitempty"`
 }
 
+// UnmarshalJSON decodes s, accepting camelCase or snake_case timestamp keys.
+func (s *Subscription) UnmarshalJSON(data []byte) error {
+	type subscription Subscription
+	return unmarshalTimestamps(data, (*subscription)(s))
+}
+
 // SubscriptionRequest represents a Paystack subscription request
 type SubscriptionRequest struct {
 	// customer code or email address
 	Customer string `json:"customer,omitempty"`
 	// plan code
-	Plan          string `json:"plan,omitempty"`
-	Authorization string `json:"authorization,omitempty"`
-	StartDate     string `json:"start,omitempty"`
+	Plan          string    `json:"plan,omitempty"`
+	Authorization string    `json:"authorization,omitempty"`
+	StartDate     Timestamp `json:"start,omitzero"`
 }
 
 // SubscriptionList is a list object for subscriptions.
-type SubscriptionList struct {
-	Meta   ListMeta
-	Values []Subscription `json:"data"`
+type SubscriptionList = List[Subscription]
+
+// SubscriptionListParams filters a list of subscriptions. All fields are optional.
+type SubscriptionListParams struct {
+	ListParams
+	Customer int `json:"customer,omitempty"` // Customer ID
+	Plan     int `json:"plan,omitempty"`     // Plan ID
 }
 
 // Create creates a new subscription
 // For more details see https://developers.paystack.co/v1.0/reference#create-subscription
 func (s *SubscriptionService) Create(subscription *SubscriptionRequest) (*Subscription, error) {
+	return s.CreateContext(context.Background(), subscription)
+}
+
+// CreateContext is like Create but uses ctx for the request.
+func (s *SubscriptionService) CreateContext(ctx context.Context, subscription *SubscriptionRequest) (*Subscription, error) {
 	u := fmt.Sprintf("/subscription")
 	sub := &Subscription{}
-	err := s.client.Call("POST", u, subscription, sub)
+	err := s.client.call(ctx, "Subscription.Create", "POST", u, subscription, sub)
 	return sub, err
 }
 
 // Update updates a subscription's properties.
 // For more details see https://developers.paystack.co/v1.0/reference#update-subscription
 func (s *SubscriptionService) Update(subscription *Subscription) (*Subscription, error) {
+	return s.UpdateContext(context.Background(), subscription)
+}
+
+// UpdateContext is like Update but uses ctx for the request.
+func (s *SubscriptionService) UpdateContext(ctx context.Context, subscription *Subscription) (*Subscription, error) {
 	u := fmt.Sprintf("subscription/%d", subscription.ID)
 	sub := &Subscription{}
-	err := s.client.Call("PUT", u, subscription, sub)
+	err := s.client.call(ctx, "Subscription.Update", "PUT", u, subscription, sub)
 	return sub, err
 }
 
 // Get returns the details of a subscription.
 // For more details see https://developers.paystack.co/v1.0/reference#fetch-subscription
 func (s *SubscriptionService) Get(id int) (*Subscription, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *SubscriptionService) GetContext(ctx context.Context, id int) (*Subscription, error) {
 	u := fmt.Sprintf("/subscription/%d", id)
 	sub := &Subscription{}
-	err := s.client.Call("GET", u, nil, sub)
+	err := s.client.call(ctx, "Subscription.Get", "GET", u, nil, sub)
 	return sub, err
 }
 
 // List returns a list of subscriptions.
 // For more details see https://developers.paystack.co/v1.0/reference#list-subscriptions
 func (s *SubscriptionService) List() (*SubscriptionList, error) {
-	return s.ListN(10, 0)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *SubscriptionService) ListContext(ctx context.Context) (*SubscriptionList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // ListN returns a list of subscriptions
 // For more details see https://developers.paystack.co/v1.0/reference#list-subscriptions
 func (s *SubscriptionService) ListN(count, offset int) (*SubscriptionList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *SubscriptionService) ListNContext(ctx context.Context, count, offset int) (*SubscriptionList, error) {
 	u := paginateURL("/subscription", count, offset)
 	sub := &SubscriptionList{}
-	err := s.client.Call("GET", u, nil, sub)
+	err := s.client.call(ctx, "Subscription.ListN", "GET", u, nil, sub)
 	return sub, err
 }
 
+// Iter returns an iterator over all subscriptions, fetching perPage of them at a time.
+func (s *SubscriptionService) Iter(ctx context.Context, perPage int) *Iterator[Subscription] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Subscription], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
+
+// ListWithParams returns a list of subscriptions matching params
+// For more details see https://paystack.com/docs/api/subscription/#list
+func (s *SubscriptionService) ListWithParams(params *SubscriptionListParams) (*SubscriptionList, error) {
+	return s.ListWithParamsContext(context.Background(), params)
+}
+
+// ListWithParamsContext is like ListWithParams but uses ctx for the request.
+func (s *SubscriptionService) ListWithParamsContext(ctx context.Context, params *SubscriptionListParams) (*SubscriptionList, error) {
+	u := filterURL("/subscription", params)
+	sub := &SubscriptionList{}
+	err := s.client.call(ctx, "Subscription.ListWithParams", "GET", u, nil, sub)
+	return sub, err
+}
+
+// IterWithParams returns an iterator over all subscriptions matching params,
+// fetching params.PerPage of them at a time. params.Page is ignored.
+func (s *SubscriptionService) IterWithParams(ctx context.Context, params SubscriptionListParams) *Iterator[Subscription] {
+	return newIterator(ctx, params.PerPage, func(ctx context.Context, perPage, page int) (*List[Subscription], error) {
+		params.ListParams = ListParams{PerPage: perPage, Page: page}
+		return s.ListWithParamsContext(ctx, &params)
+	})
+}
+
 // Enable enables a subscription
 // For more details see https://developers.paystack.co/v1.0/reference#enable-subscription
 func (s *SubscriptionService) Enable(subscriptionCode, emailToken string) (Response, error) {
+	return s.EnableContext(context.Background(), subscriptionCode, emailToken)
+}
+
+// EnableContext is like Enable but uses ctx for the request.
+func (s *SubscriptionService) EnableContext(ctx context.Context, subscriptionCode, emailToken string) (Response, error) {
 	params := url.Values{}
 	params.Add("code", subscriptionCode)
 	params.Add("token", emailToken)
 	resp := Response{}
-	err := s.client.Call("POST", "/subscription/enable", params, &resp)
+	err := s.client.call(ctx, "Subscription.Enable", "POST", "/subscription/enable", params, &resp)
 	return resp, err
 }
 
 // Disable disables a subscription
 // For more details see https://developers.paystack.co/v1.0/reference#disable-subscription
 func (s *SubscriptionService) Disable(subscriptionCode, emailToken string) (Response, error) {
+	return s.DisableContext(context.Background(), subscriptionCode, emailToken)
+}
+
+// DisableContext is like Disable but uses ctx for the request.
+func (s *SubscriptionService) DisableContext(ctx context.Context, subscriptionCode, emailToken string) (Response, error) {
 	params := url.Values{}
 	params.Add("code", subscriptionCode)
 	params.Add("token", emailToken)
 	resp := Response{}
-	err := s.client.Call("POST", "/subscription/disable", params, &resp)
+	err := s.client.call(ctx, "Subscription.Disable", "POST", "/subscription/disable", params, &resp)
 	return resp, err
 }
@@ -3,6 +3,8 @@ package paystack
 import "testing"
 
 func TestSubscriptionCRUD(t *testing.T) {
+	useClient(t)
+
 	cust := &Customer{
 		FirstName: "User123",
 		LastName:  "AdminUser",
@@ -18,7 +20,7 @@ func TestSubscriptionCRUD(t *testing.T) {
 	plan1 := &Plan{
 		Name:     "Monthly subscription retainer",
 		Interval: "monthly",
-		Amount:   250000,
+		Amount:   NewMoney(250000, "NGN"),
 	}
 
 	// create the plan
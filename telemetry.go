@@ -0,0 +1,199 @@
+package paystack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Tracer starts a span for each API call. It is a small subset of the
+// OpenTelemetry tracing API, so that this package does not depend on it;
+// an adapter around an OpenTelemetry trace.Tracer and propagator is a few
+// lines long.
+type Tracer interface {
+	// Start starts a span named name as a child of any span in ctx, and
+	// returns a context carrying the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+
+	// Inject writes the span context of ctx into the outgoing request
+	// header, e.g. as a W3C traceparent header.
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is a single traced API call.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Attribute is a key-value pair attached to a span. Value is a string,
+// an int or a bool.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span attribute keys. The HTTP attributes follow the OpenTelemetry
+// semantic conventions.
+const (
+	AttrHTTPMethod    = "http.request.method"
+	AttrHTTPStatus    = "http.response.status_code"
+	AttrRoute         = "url.template"
+	AttrServerAddress = "server.address"
+	AttrErrorType     = "error.type"
+	AttrOperation     = "paystack.operation"
+	AttrAttempts      = "paystack.attempts"
+)
+
+// Metrics records a measurement for each API call, e.g. to increment a
+// Prometheus counter and observe latency and error histograms.
+type Metrics interface {
+	RecordRequest(ctx context.Context, m RequestMetric)
+}
+
+// RequestMetric describes a completed API call.
+type RequestMetric struct {
+	Operation  Operation
+	Method     string
+	Route      string // route template, e.g. /transaction/verify/{id}
+	StatusCode int    // 0 if no response was received
+	ErrorClass string // empty on success, see ErrorClass
+	Latency    time.Duration
+	Attempts   int
+}
+
+// ErrorClass returns a short, low-cardinality name for the kind of err,
+// suitable for a metric label: unauthorized, not_found, rate_limited,
+// duplicate_reference, validation, insufficient_balance, server, api,
+// circuit_open, canceled, timeout or transport. It returns "" for nil.
+func ErrorClass(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrDuplicateReference):
+		return "duplicate_reference"
+	case errors.Is(err, ErrValidation):
+		return "validation"
+	case errors.Is(err, ErrInsufficientBalance):
+		return "insufficient_balance"
+	case errors.Is(err, ErrServer):
+		return "server"
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return "api"
+	}
+	return "transport"
+}
+
+// routeSegments are the fixed path segments of the Paystack API. Any other
+// segment is an ID, code, reference or email address.
+var routeSegments = map[string]bool{}
+
+func init() {
+	for _, s := range strings.Fields(`
+		add assign available_providers balance bank bin bulkcharge bvn
+		charge charge_authorization charges check_reauthorization
+		check_slug_availability customer deactivate deactivate_authorization
+		decision dedicated_account disable disable_otp disable_otp_finalize
+		dispute email enable enable_otp enable_otp_finalize evidence export
+		finalize_transfer identification initialize integration ledger link
+		manage match page partial_debit pause payment_session_timeout plan
+		product refund remove requery request_reauthorization resend_otp
+		resolve resolve_bvn resume set_risk_action settlement split
+		subaccount submit_address submit_birthday submit_otp submit_phone
+		submit_pin subscription timeline tokenize totals transaction
+		transactions transfer transferrecipient upload_url verify`) {
+		routeSegments[s] = true
+	}
+}
+
+// routeTemplate returns path, relative to the API base, with every
+// variable segment replaced by {id}, so that no identifiers reach traces
+// or metric labels.
+func routeTemplate(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if s != "" && !routeSegments[s] {
+			segments[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// instrument starts a span for inv and returns a function that finishes
+// it and records metrics once the call has returned err.
+func (c *Client) instrument(ctx context.Context, inv *Invocation) (context.Context, func(err error)) {
+	if c.Tracer == nil && c.Metrics == nil {
+		return ctx, func(error) {}
+	}
+
+	route := routeTemplate(c.endpoint(inv.Request.URL))
+	name := inv.Operation.String()
+	if name == "" {
+		name = "Call"
+	}
+
+	var span Span
+	if c.Tracer != nil {
+		ctx, span = c.Tracer.Start(ctx, "paystack."+name)
+		span.SetAttributes(
+			Attribute{AttrOperation, name},
+			Attribute{AttrHTTPMethod, inv.Request.Method},
+			Attribute{AttrRoute, route},
+			Attribute{AttrServerAddress, inv.Request.URL.Host},
+		)
+		inv.Request = inv.Request.WithContext(ctx)
+		c.Tracer.Inject(ctx, inv.Request.Header)
+	}
+
+	start := time.Now()
+	return ctx, func(err error) {
+		m := RequestMetric{
+			Operation:  inv.Operation,
+			Method:     inv.Request.Method,
+			Route:      route,
+			ErrorClass: ErrorClass(err),
+			Latency:    time.Since(start),
+		}
+		if inv.Response != nil {
+			m.StatusCode = inv.Response.StatusCode
+			m.Attempts = inv.Response.Attempts
+		}
+
+		if span != nil {
+			if m.StatusCode != 0 {
+				span.SetAttributes(Attribute{AttrHTTPStatus, m.StatusCode})
+			}
+			if m.Attempts != 0 {
+				span.SetAttributes(Attribute{AttrAttempts, m.Attempts})
+			}
+			if err != nil {
+				span.SetAttributes(Attribute{AttrErrorType, m.ErrorClass})
+				span.RecordError(err)
+			}
+			span.End()
+		}
+		if c.Metrics != nil {
+			c.Metrics.RecordRequest(ctx, m)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package paystack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type spanKey struct{}
+
+type testSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *testSpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+func (s *testSpan) RecordError(err error) { s.err = err }
+func (s *testSpan) End()                  { s.ended = true }
+
+type testTracer struct{ spans []*testSpan }
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	s := &testSpan{name: name, attrs: map[string]interface{}{}}
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func (t *testTracer) Inject(ctx context.Context, header http.Header) {
+	if s, ok := ctx.Value(spanKey{}).(*testSpan); ok {
+		header.Set("traceparent", s.name)
+	}
+}
+
+type testMetrics struct{ requests []RequestMetric }
+
+func (m *testMetrics) RecordRequest(ctx context.Context, r RequestMetric) {
+	m.requests = append(m.requests, r)
+}
+
+func TestTelemetry(t *testing.T) {
+	var traceparents []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		if r.URL.Path == "/transaction/verify/missing-ref" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":false,"message":"Transaction reference not found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":true,"message":"ok","data":{"id":1}}`)
+	}))
+	defer srv.Close()
+
+	tracer, metrics := &testTracer{}, &testMetrics{}
+	client, err := NewClientWithOptions("sk_test_key", WithBaseURL(srv.URL), WithTracer(tracer), WithMetrics(metrics))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Transaction.Verify("ref-123"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Transaction.Verify("missing-ref"); err == nil {
+		t.Fatal("Expected an error for a missing transaction")
+	}
+
+	if len(tracer.spans) != 2 || len(metrics.requests) != 2 {
+		t.Fatalf("Expected 2 spans and 2 metrics, got %d and %d", len(tracer.spans), len(metrics.requests))
+	}
+	ok, failed := tracer.spans[0], tracer.spans[1]
+	if ok.name != "paystack.Transaction.Verify" || !ok.ended || ok.err != nil {
+		t.Errorf("Unexpected span %+v", ok)
+	}
+	if ok.attrs[AttrRoute] != "/transaction/verify/{id}" || ok.attrs[AttrHTTPMethod] != "GET" || ok.attrs[AttrHTTPStatus] != 200 {
+		t.Errorf("Unexpected span attributes %v", ok.attrs)
+	}
+	if failed.err == nil || failed.attrs[AttrErrorType] != "not_found" || failed.attrs[AttrHTTPStatus] != 404 {
+		t.Errorf("Expected failed span to record a not_found error, got %+v", failed)
+	}
+	if traceparents[0] != "paystack.Transaction.Verify" {
+		t.Errorf("Expected span context to be propagated, got headers %v", traceparents)
+	}
+
+	m := metrics.requests[1]
+	if m.Operation.String() != "Transaction.Verify" || m.Route != "/transaction/verify/{id}" ||
+		m.StatusCode != 404 || m.ErrorClass != "not_found" || m.Attempts != 1 || m.Latency <= 0 {
+		t.Errorf("Unexpected metric %+v", m)
+	}
+}
+
+func TestRouteTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/transaction/verify/Txn-1700000000":      "/transaction/verify/{id}",
+		"/customer/CUS_xnxdt6s1zg1f4nx":           "/customer/{id}",
+		"/customer/user@example.com":              "/customer/{id}",
+		"/bank/resolve_bvn/12345678901":           "/bank/resolve_bvn/{id}",
+		"/bank/resolve?account_number=0022728151": "/bank/resolve",
+		"/split/12/subaccount/add":                "/split/{id}/subaccount/add",
+		"/dispute/7/upload_url":                   "/dispute/{id}/upload_url",
+		"/transferrecipient":                      "/transferrecipient",
+	}
+	for path, want := range tests {
+		if got := routeTemplate(path); got != want {
+			t.Errorf("Expected route of %s to be %s, got %s", path, want, got)
+		}
+	}
+
+	u, _ := url.Parse("https://api.paystack.co/")
+	if got := (&Client{baseURL: u}).endpoint(&url.URL{Path: "/plan/PLN_1"}); routeTemplate(got) != "/plan/{id}" {
+		t.Errorf("Expected /plan/{id}, got %s", routeTemplate(got))
+	}
+}
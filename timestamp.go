@@ -0,0 +1,162 @@
+package paystack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// timestampLayouts are the formats Paystack writes timestamps in. Layouts
+// without a zone are read as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano, // 2024-01-02T10:00:00.000Z
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Timestamp is a time decoded from any of the formats Paystack uses:
+// ISO 8601 with or without a zone or fraction, a plain date, or unix
+// seconds as some subscription fields are returned. A null or empty
+// value decodes to the zero Timestamp.
+//
+// Timestamps are encoded in RFC 3339 format, and a zero Timestamp as null.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp returns a Timestamp for t.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{t}
+}
+
+// ParseTimestamp parses s in any of the formats Paystack uses.
+func ParseTimestamp(s string) (Timestamp, error) {
+	if s == "" {
+		return Timestamp{}, nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Timestamp{t}, nil
+		}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return unixTimestamp(n), nil
+	}
+	return Timestamp{}, fmt.Errorf("paystack: cannot parse %q as a timestamp", s)
+}
+
+// unixTimestamp converts unix seconds, or milliseconds for values too
+// large to be seconds, to a Timestamp.
+func unixTimestamp(n int64) Timestamp {
+	if n > 1e12 || n < -1e12 {
+		return Timestamp{time.UnixMilli(n).UTC()}
+	}
+	return Timestamp{time.Unix(n, 0).UTC()}
+}
+
+// MarshalJSON encodes t in RFC 3339 format, or as null if t is zero.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(time.RFC3339Nano))
+}
+
+// UnmarshalJSON decodes a timestamp string or unix time.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+	if len(data) > 0 && data[0] != '"' {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("paystack: cannot decode %s as a timestamp", data)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return fmt.Errorf("paystack: cannot decode %s as a timestamp", data)
+		}
+		*t = unixTimestamp(i)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	ts, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*t = ts
+	return nil
+}
+
+var timestampType = reflect.TypeOf(Timestamp{})
+
+// unmarshalTimestamps decodes data into v, a pointer to a struct without
+// an UnmarshalJSON method. Paystack spells timestamp keys in camelCase in
+// some responses and in snake_case in others, such as createdAt and
+// created_at, so each Timestamp field left zero is then read from the
+// other spelling of its key.
+func unmarshalTimestamps(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(v).Elem()
+	var fields map[string]json.RawMessage
+	for i := 0; i < rv.NumField(); i++ {
+		f := rv.Type().Field(i)
+		if f.Type != timestampType || !rv.Field(i).IsZero() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		alt := otherSpelling(name)
+		if alt == name || !bytes.Contains(data, []byte(`"`+alt+`"`)) {
+			continue
+		}
+		if fields == nil {
+			if err := json.Unmarshal(data, &fields); err != nil {
+				return err
+			}
+		}
+		if raw, ok := fields[alt]; ok {
+			if err := rv.Field(i).Addr().Interface().(*Timestamp).UnmarshalJSON(raw); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// otherSpelling converts a camelCase key to snake_case and a snake_case
+// key to camelCase
+func otherSpelling(key string) string {
+	var b strings.Builder
+	if strings.Contains(key, "_") {
+		for i, part := range strings.Split(key, "_") {
+			if i > 0 && part != "" {
+				part = strings.ToUpper(part[:1]) + part[1:]
+			}
+			b.WriteString(part)
+		}
+		return b.String()
+	}
+	for _, r := range key {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package paystack
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampUnmarshal(t *testing.T) {
+	want := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		`"2024-01-02T10:00:00.000Z"`:  want,
+		`"2024-01-02T10:00:00Z"`:      want,
+		`"2024-01-02T11:00:00+01:00"`: want,
+		`"2024-01-02T10:00:00.000"`:   want,
+		`"2024-01-02 10:00:00"`:       want,
+		`"2024-01-02"`:                time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		`1704189600`:                  want,
+		`1704189600000`:               want,
+		`"1704189600"`:                want,
+		`null`:                        {},
+		`""`:                          {},
+	}
+	for body, want := range tests {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(body), &ts); err != nil {
+			t.Errorf("Unmarshal(%s): %v", body, err)
+			continue
+		}
+		if !ts.Equal(want) {
+			t.Errorf("Expected %s to decode to %v, got %v", body, want, ts.Time)
+		}
+	}
+
+	for _, body := range []string{`"yesterday"`, `true`, `1.5`} {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(body), &ts); err == nil {
+			t.Errorf("Expected %s to fail to decode, got %v", body, ts.Time)
+		}
+	}
+}
+
+func TestTimestampMarshal(t *testing.T) {
+	b, err := json.Marshal(&SubscriptionRequest{
+		Customer:  "CUS_1",
+		StartDate: NewTimestamp(time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"customer":"CUS_1","start":"2024-05-01T08:30:00Z"}` {
+		t.Errorf("Expected start in RFC 3339 format, got %s", b)
+	}
+
+	b, _ = json.Marshal(&SubscriptionRequest{Customer: "CUS_1"})
+	if string(b) != `{"customer":"CUS_1"}` {
+		t.Errorf("Expected a zero start to be left out, got %s", b)
+	}
+}
+
+func TestDecodeTimestamps(t *testing.T) {
+	var sub Subscription
+	body := `{"id":1,"createdAt":"2024-01-02T10:00:00.000Z","start":1704189600,"next_payment_date":null}`
+	if err := json.Unmarshal([]byte(body), &sub); err != nil {
+		t.Fatal(err)
+	}
+	if !sub.CreatedAt.Equal(sub.StartDate.Time) || !sub.NextPaymentDate.IsZero() {
+		t.Errorf("Expected mixed timestamp formats to decode, got %+v", sub)
+	}
+}
+
+func TestDecodeTimestampKeySpellings(t *testing.T) {
+	want := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	for _, body := range []string{
+		`{"id":1,"createdAt":"2024-01-02T10:00:00.000Z","updatedAt":"2024-01-02T10:00:00.000Z"}`,
+		`{"id":1,"created_at":"2024-01-02T10:00:00.000Z","updated_at":"2024-01-02T10:00:00.000Z"}`,
+	} {
+		var c Customer
+		var dva DedicatedVirtualAccount
+		if err := json.Unmarshal([]byte(body), &c); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(body), &dva); err != nil {
+			t.Fatal(err)
+		}
+		if c.ID != 1 || !c.CreatedAt.Equal(want) || !c.UpdatedAt.Equal(want) {
+			t.Errorf("%s: got customer %+v", body, c)
+		}
+		if !dva.CreatedAt.Equal(want) || !dva.UpdatedAt.Equal(want) {
+			t.Errorf("%s: got dedicated account %+v", body, dva)
+		}
+	}
+
+	var c Customer
+	json.Unmarshal([]byte(`{"createdAt":"2024-01-02T10:00:00.000Z","created_at":"2020-01-01"}`), &c)
+	if !c.CreatedAt.Equal(want) {
+		t.Errorf("Expected the tagged key to take precedence, got %v", c.CreatedAt)
+	}
+
+	var d Dispute
+	if err := json.Unmarshal([]byte(`{"id":1,"due_at":"2024-01-02T10:00:00.000Z"}`), &d); err != nil || !d.DueAt.Equal(want) {
+		t.Errorf("Expected due_at to decode into DueAt, got %v, %v", d.DueAt, err)
+	}
+	if err := json.Unmarshal([]byte(`{"id":1,"resolved_at":"yesterday"}`), &d); err == nil {
+		t.Errorf("Expected a bad timestamp under the other spelling to fail")
+	}
+}
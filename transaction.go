@@ -1,15 +1,29 @@
 package paystack
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 // TransactionService handles operations related to transactions
 // For more details see https://developers.paystack.co/v1.0/reference#create-transaction
 type TransactionService service
 
 // TransactionList is a list object for transactions.
-type TransactionList struct {
-	Meta   ListMeta
-	Values []Transaction `json:"data"`
+type TransactionList = List[Transaction]
+
+// TransactionListParams filters a list of transactions. All fields are optional.
+type TransactionListParams struct {
+	ListParams
+	Customer   int       `json:"customer,omitempty"` // Customer ID
+	TerminalID string    `json:"terminalid,omitempty"`
+	Status     string    `json:"status,omitempty"` // success, failed or abandoned
+	From       time.Time `json:"from,omitempty"`
+	To         time.Time `json:"to,omitempty"`
+	Amount     Money     `json:"amount,omitzero"`
+	Currency   string    `json:"currency,omitempty"`
+	Channel    string    `json:"channel,omitempty"`
 }
 
 // TransactionRequest represents a request to start a transaction.
@@ -18,13 +32,13 @@ type TransactionRequest struct {
 	Reference         string   `json:"reference,omitempty"`
 	AuthorizationCode string   `json:"authorization_code,omitempty"`
 	Currency          string   `json:"currency,omitempty"`
-	Amount            float32  `json:"amount,omitempty"`
+	Amount            Money    `json:"amount,omitzero"`
 	Email             string   `json:"email,omitempty"`
 	Plan              string   `json:"plan,omitempty"`
 	InvoiceLimit      int      `json:"invoice_limit,omitempty"`
 	Metadata          Metadata `json:"metadata,omitempty"`
 	SubAccount        string   `json:"subaccount,omitempty"`
-	TransactionCharge int      `json:"transaction_charge,omitempty"`
+	TransactionCharge Money    `json:"transaction_charge,omitzero"`
 	Bearer            string   `json:"bearer,omitempty"`
 	Channels          []string `json:"channels,omitempty"`
 }
@@ -33,7 +47,7 @@ type TransactionRequest struct {
 type AuthorizationRequest struct {
 	Reference         string   `json:"reference,omitempty"`
 	AuthorizationCode string   `json:"authorization_code,omitempty"`
-	Amount            int      `json:"amount,omitempty"`
+	Amount            Money    `json:"amount,omitzero"`
 	Currency          string   `json:"currency,omitempty"`
 	Email             string   `json:"email,omitempty"`
 	Metadata          Metadata `json:"metadata,omitempty"`
@@ -43,25 +57,31 @@ type AuthorizationRequest struct {
 // For more details see https://developers.paystack.co/v1.0/reference#initialize-a-transaction
 type Transaction struct {
 	ID              int                    `json:"id,omitempty"`
-	CreatedAt       string                 `json:"createdAt,omitempty"`
+	CreatedAt       Timestamp              `json:"createdAt,omitzero"`
 	Domain          string                 `json:"domain,omitempty"`
-	Metadata        string                 `json:"metadata,omitempty"` //TODO: why is transaction metadata a string?
+	Metadata        interface{}            `json:"metadata,omitempty"` // an object, or a string when set as one
 	Status          string                 `json:"status,omitempty"`
 	Reference       string                 `json:"reference,omitempty"`
-	Amount          float32                `json:"amount,omitempty"`
+	Amount          Money                  `json:"amount,omitzero"`
 	Message         string                 `json:"message,omitempty"`
 	GatewayResponse string                 `json:"gateway_response,omitempty"`
-	PaidAt          string                 `json:"piad_at,omitempty"`
+	PaidAt          Timestamp              `json:"paid_at,omitzero"`
 	Channel         string                 `json:"channel,omitempty"`
 	Currency        string                 `json:"currency,omitempty"`
 	IPAddress       string                 `json:"ip_address,omitempty"`
 	Log             map[string]interface{} `json:"log,omitempty"` // TODO: same as timeline?
-	Fees            int                    `json:"int,omitempty"`
-	FeesSplit       string                 `json:"fees_split,omitempty"` // TODO: confirm data type
+	Fees            Money                  `json:"fees,omitzero"`
+	FeesSplit       interface{}            `json:"fees_split,omitempty"`
 	Customer        Customer               `json:"customer,omitempty"`
 	Authorization   Authorization          `json:"authorization,omitempty"`
 	Plan            Plan                   `json:"plan,omitempty"`
-	SubAccount      SubAccount             `json:"sub_account,omitempty"`
+	SubAccount      SubAccount             `json:"subaccount,omitempty"`
+}
+
+// UnmarshalJSON decodes t, accepting camelCase or snake_case timestamp keys.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	type transaction Transaction
+	return unmarshalTimestamps(data, (*transaction)(t))
 }
 
 // Authorization represents Paystack authorization object
@@ -96,94 +116,201 @@ type TransactionTimeline struct {
 // Initialize initiates a transaction process
 // For more details see https://developers.paystack.co/v1.0/reference#initialize-a-transaction
 func (s *TransactionService) Initialize(txn *TransactionRequest) (Response, error) {
+	return s.InitializeContext(context.Background(), txn)
+}
+
+// InitializeContext is like Initialize but uses ctx for the request.
+func (s *TransactionService) InitializeContext(ctx context.Context, txn *TransactionRequest) (Response, error) {
 	u := fmt.Sprintf("/transaction/initialize")
 	resp := Response{}
-	err := s.client.Call("POST", u, txn, &resp)
+	err := s.client.call(ctx, "Transaction.Initialize", "POST", u, txn, &resp)
 	return resp, err
 }
 
 // Verify checks that transaction with the given reference exists
 // For more details see https://api.paystack.co/transaction/verify/reference
 func (s *TransactionService) Verify(reference string) (*Transaction, error) {
+	return s.VerifyContext(context.Background(), reference)
+}
+
+// VerifyContext is like Verify but uses ctx for the request.
+func (s *TransactionService) VerifyContext(ctx context.Context, reference string) (*Transaction, error) {
 	u := fmt.Sprintf("/transaction/verify/%s", reference)
 	txn := &Transaction{}
-	err := s.client.Call("GET", u, nil, txn)
+	err := s.client.call(ctx, "Transaction.Verify", "GET", u, nil, txn)
 	return txn, err
 }
 
 // List returns a list of transactions.
 // For more details see https://paystack.com/docs/api/#transaction-list
 func (s *TransactionService) List() (*TransactionList, error) {
-	return s.ListN(10, 1)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *TransactionService) ListContext(ctx context.Context) (*TransactionList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // ListN returns a list of transactions
 // For more details see https://developers.paystack.co/v1.0/reference#list-transactions
 func (s *TransactionService) ListN(count, offset int) (*TransactionList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *TransactionService) ListNContext(ctx context.Context, count, offset int) (*TransactionList, error) {
 	u := paginateURL("/transaction", count, offset)
 	txns := &TransactionList{}
-	err := s.client.Call("GET", u, nil, txns)
+	err := s.client.call(ctx, "Transaction.ListN", "GET", u, nil, txns)
 	return txns, err
 }
 
+// Iter returns an iterator over all transactions, fetching perPage of them at a time.
+func (s *TransactionService) Iter(ctx context.Context, perPage int) *Iterator[Transaction] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Transaction], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
+
+// ListWithParams returns a list of transactions matching params
+// For more details see https://paystack.com/docs/api/transaction/#list
+func (s *TransactionService) ListWithParams(params *TransactionListParams) (*TransactionList, error) {
+	return s.ListWithParamsContext(context.Background(), params)
+}
+
+// ListWithParamsContext is like ListWithParams but uses ctx for the request.
+func (s *TransactionService) ListWithParamsContext(ctx context.Context, params *TransactionListParams) (*TransactionList, error) {
+	u := filterURL("/transaction", params)
+	txns := &TransactionList{}
+	err := s.client.call(ctx, "Transaction.ListWithParams", "GET", u, nil, txns)
+	return txns, err
+}
+
+// IterWithParams returns an iterator over all transactions matching params,
+// fetching params.PerPage of them at a time. params.Page is ignored.
+func (s *TransactionService) IterWithParams(ctx context.Context, params TransactionListParams) *Iterator[Transaction] {
+	return newIterator(ctx, params.PerPage, func(ctx context.Context, perPage, page int) (*List[Transaction], error) {
+		params.ListParams = ListParams{PerPage: perPage, Page: page}
+		return s.ListWithParamsContext(ctx, &params)
+	})
+}
+
+// ListCursor returns the page of transactions selected by cursor, using cursor pagination
+// For more details see https://paystack.com/docs/api/pagination/#cursor-pagination
+func (s *TransactionService) ListCursor(count int, cursor Cursor) (*TransactionList, error) {
+	return s.ListCursorContext(context.Background(), count, cursor)
+}
+
+// ListCursorContext is like ListCursor but uses ctx for the request.
+func (s *TransactionService) ListCursorContext(ctx context.Context, count int, cursor Cursor) (*TransactionList, error) {
+	u := cursorURL("/transaction", count, cursor)
+	txns := &TransactionList{}
+	err := s.client.call(ctx, "Transaction.ListCursor", "GET", u, nil, txns)
+	return txns, err
+}
+
+// IterCursor returns an iterator over all transactions using cursor pagination,
+// which stays consistent while new transactions are being created.
+func (s *TransactionService) IterCursor(ctx context.Context, perPage int) *Iterator[Transaction] {
+	return newCursorIterator(ctx, perPage, func(ctx context.Context, perPage int, cursor Cursor) (*List[Transaction], error) {
+		return s.ListCursorContext(ctx, perPage, cursor)
+	})
+}
+
 // Get returns the details of a transaction.
 // For more details see https://developers.paystack.co/v1.0/reference#fetch-transaction
 func (s *TransactionService) Get(id int) (*Transaction, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *TransactionService) GetContext(ctx context.Context, id int) (*Transaction, error) {
 	u := fmt.Sprintf("/transaction/%d", id)
 	txn := &Transaction{}
-	err := s.client.Call("GET", u, nil, txn)
+	err := s.client.call(ctx, "Transaction.Get", "GET", u, nil, txn)
 	return txn, err
 }
 
 // ChargeAuthorization is for charging all  authorizations marked as reusable whenever you need to recieve payments.
 // For more details see https://developers.paystack.co/v1.0/reference#charge-authorization
 func (s *TransactionService) ChargeAuthorization(req *TransactionRequest) (*Transaction, error) {
+	return s.ChargeAuthorizationContext(context.Background(), req)
+}
+
+// ChargeAuthorizationContext is like ChargeAuthorization but uses ctx for the request.
+func (s *TransactionService) ChargeAuthorizationContext(ctx context.Context, req *TransactionRequest) (*Transaction, error) {
 	txn := &Transaction{}
-	err := s.client.Call("POST", "/transaction/charge_authorization", req, txn)
+	err := s.client.call(ctx, "Transaction.ChargeAuthorization", "POST", "/transaction/charge_authorization", req, txn)
 	return txn, err
 }
 
 // Timeline fetches the transaction timeline. Reference can be ID or transaction reference
 // For more details see https://developers.paystack.co/v1.0/reference#view-transaction-timeline
 func (s *TransactionService) Timeline(reference string) (*TransactionTimeline, error) {
+	return s.TimelineContext(context.Background(), reference)
+}
+
+// TimelineContext is like Timeline but uses ctx for the request.
+func (s *TransactionService) TimelineContext(ctx context.Context, reference string) (*TransactionTimeline, error) {
 	u := fmt.Sprintf("/transaction/timeline/%s", reference)
 	timeline := &TransactionTimeline{}
-	err := s.client.Call("GET", u, nil, timeline)
+	err := s.client.call(ctx, "Transaction.Timeline", "GET", u, nil, timeline)
 	return timeline, err
 }
 
 // Totals returns total amount received on your account
 // For more details see https://developers.paystack.co/v1.0/reference#transaction-totals
 func (s *TransactionService) Totals() (Response, error) {
+	return s.TotalsContext(context.Background())
+}
+
+// TotalsContext is like Totals but uses ctx for the request.
+func (s *TransactionService) TotalsContext(ctx context.Context) (Response, error) {
 	u := fmt.Sprintf("/transaction/totals")
 	resp := Response{}
-	err := s.client.Call("GET", u, nil, &resp)
+	err := s.client.call(ctx, "Transaction.Totals", "GET", u, nil, &resp)
 	return resp, err
 }
 
 // Export exports transactions to a downloadable file and returns a link to the file
 // For more details see https://developers.paystack.co/v1.0/reference#export-transactions
 func (s *TransactionService) Export(params RequestValues) (Response, error) {
+	return s.ExportContext(context.Background(), params)
+}
+
+// ExportContext is like Export but uses ctx for the request.
+func (s *TransactionService) ExportContext(ctx context.Context, params RequestValues) (Response, error) {
 	u := fmt.Sprintf("/transaction/export")
 	resp := Response{}
-	err := s.client.Call("GET", u, nil, &resp)
+	err := s.client.call(ctx, "Transaction.Export", "GET", u, nil, &resp)
 	return resp, err
 }
 
 // ReAuthorize requests reauthorization
 // For more details see https://developers.paystack.co/v1.0/reference#request-reauthorization
 func (s *TransactionService) ReAuthorize(req AuthorizationRequest) (Response, error) {
+	return s.ReAuthorizeContext(context.Background(), req)
+}
+
+// ReAuthorizeContext is like ReAuthorize but uses ctx for the request.
+func (s *TransactionService) ReAuthorizeContext(ctx context.Context, req AuthorizationRequest) (Response, error) {
 	u := fmt.Sprintf("/transaction/request_reauthorization")
 	resp := Response{}
-	err := s.client.Call("POST", u, nil, &resp)
+	err := s.client.call(ctx, "Transaction.ReAuthorize", "POST", u, nil, &resp)
 	return resp, err
 }
 
 // CheckAuthorization checks authorization
 // For more details see https://developers.paystack.co/v1.0/reference#check-authorization
 func (s *TransactionService) CheckAuthorization(req AuthorizationRequest) (Response, error) {
+	return s.CheckAuthorizationContext(context.Background(), req)
+}
+
+// CheckAuthorizationContext is like CheckAuthorization but uses ctx for the request.
+func (s *TransactionService) CheckAuthorizationContext(ctx context.Context, req AuthorizationRequest) (Response, error) {
 	u := fmt.Sprintf("/transaction/check_reauthorization")
 	resp := Response{}
-	err := s.client.Call("POST", u, nil, &resp)
+	err := s.client.call(ctx, "Transaction.CheckAuthorization", "POST", u, nil, &resp)
 	return resp, err
 }
@@ -11,9 +11,11 @@ func makeTimestamp() int64 {
 }
 
 func TestInitializeTransaction(t *testing.T) {
+	useClient(t)
+
 	txn := &TransactionRequest{
 		Email:     "user123@gmail.com",
-		Amount:    6000,
+		Amount:    NewMoney(6000, "NGN"),
 		Reference: "Txn-" + fmt.Sprintf("%d", makeTimestamp()),
 	}
 	resp, err := c.Transaction.Initialize(txn)
@@ -40,7 +42,7 @@ func TestInitializeTransaction(t *testing.T) {
 	}
 
 	if txn1.Amount != txn.Amount {
-		t.Errorf("Expected transaction amount %f, got %+v", txn.Amount, txn1.Amount)
+		t.Errorf("Expected transaction amount %v, got %v", txn.Amount, txn1.Amount)
 	}
 
 	if txn1.Reference == "" {
@@ -55,6 +57,8 @@ func TestInitializeTransaction(t *testing.T) {
 }
 
 func TestTransactionList(t *testing.T) {
+	useClient(t)
+
 	// retrieve the transaction list
 	transactions, err := c.Transaction.List()
 	if err != nil {
@@ -63,6 +67,8 @@ func TestTransactionList(t *testing.T) {
 }
 
 func TestTransactionTotals(t *testing.T) {
+	useClient(t)
+
 	_, err := c.Transaction.Totals()
 	if err != nil {
 		t.Error(err)
@@ -70,6 +76,8 @@ func TestTransactionTotals(t *testing.T) {
 }
 
 func TestExportTransaction(t *testing.T) {
+	useClient(t)
+
 	resp, err := c.Transaction.Export(nil)
 	if err != nil {
 		t.Error(err)
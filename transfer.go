@@ -1,8 +1,10 @@
 package paystack
 
 import (
+	"context"
 	"fmt"
 	"net/url"
+	"time"
 )
 
 // TransferService handles operations related to the transfer
@@ -11,42 +13,50 @@ type TransferService service
 
 // TransferRequest represents a request to create a transfer.
 type TransferRequest struct {
-	Source    string  `json:"source,omitempty"`
-	Amount    float32 `json:"amount,omitempty"`
-	Currency  string  `json:"currency,omitempty"`
-	Reason    string  `json:"reason,omitempty"`
-	Recipient string  `json:"recipient,omitempty"`
+	Source    string `json:"source,omitempty"`
+	Amount    Money  `json:"amount,omitzero"`
+	Currency  string `json:"currency,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Recipient string `json:"recipient,omitempty"`
+	Reference string `json:"reference,omitempty"` // Optional: unique lowercase reference, see ULIDReferences
 }
 
 // Transfer is the resource representing your Paystack transfer.
 // For more details see https://developers.paystack.co/v1.0/reference#initiate-transfer
 type Transfer struct {
-	ID           int     `json:"id,omitempty"`
-	CreatedAt    string  `json:"createdAt,omitempty"`
-	UpdatedAt    string  `json:"updatedAt,omitempty"`
-	Domain       string  `json:"domain,omitempty"`
-	Integration  int     `json:"integration,omitempty"`
-	Source       string  `json:"source,omitempty"`
-	Amount       float32 `json:"amount,omitempty"`
-	Currency     string  `json:"currency,omitempty"`
-	Reason       string  `json:"reason,omitempty"`
-	TransferCode string  `json:"transfer_code,omitempty"`
+	ID           int       `json:"id,omitempty"`
+	CreatedAt    Timestamp `json:"createdAt,omitzero"`
+	UpdatedAt    Timestamp `json:"updatedAt,omitzero"`
+	Domain       string    `json:"domain,omitempty"`
+	Integration  int       `json:"integration,omitempty"`
+	Source       string    `json:"source,omitempty"`
+	Amount       Money     `json:"amount,omitzero"`
+	Currency     string    `json:"currency,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+	Reference    string    `json:"reference,omitempty"`
+	TransferCode string    `json:"transfer_code,omitempty"`
 	// Initiate returns recipient ID as recipient value, Fetch returns recipient object
 	Recipient interface{} `json:"recipient,omitempty"`
 	Status    string      `json:"status,omitempty"`
 	// confirm types for source_details and failures
 	SourceDetails interface{} `json:"source_details,omitempty"`
 	Failures      interface{} `json:"failures,omitempty"`
-	TransferredAt string      `json:"transferred_at,omitempty"`
+	TransferredAt Timestamp   `json:"transferred_at,omitzero"`
 	TitanCode     string      `json:"titan_code,omitempty"`
 }
 
+// UnmarshalJSON decodes t, accepting camelCase or snake_case timestamp keys.
+func (t *Transfer) UnmarshalJSON(data []byte) error {
+	type transfer Transfer
+	return unmarshalTimestamps(data, (*transfer)(t))
+}
+
 // TransferRecipient represents a Paystack transfer recipient
 // For more details see https://developers.paystack.co/v1.0/reference#create-transfer-recipient
 type TransferRecipient struct {
 	ID            int                    `json:"id,omitempty"`
-	CreatedAt     string                 `json:"createdAt,omitempty"`
-	UpdatedAt     string                 `json:"updatedAt,omitempty"`
+	CreatedAt     Timestamp              `json:"createdAt,omitzero"`
+	UpdatedAt     Timestamp              `json:"updatedAt,omitzero"`
 	Type          string                 `json:",omitempty"`
 	Name          string                 `json:"name,omitempty"`
 	Metadata      Metadata               `json:"metadata,omitempty"`
@@ -60,6 +70,12 @@ type TransferRecipient struct {
 	RecipientCode string                 `json:"recipient_code,omitempty"`
 }
 
+// UnmarshalJSON decodes r, accepting camelCase or snake_case timestamp keys.
+func (r *TransferRecipient) UnmarshalJSON(data []byte) error {
+	type transferRecipient TransferRecipient
+	return unmarshalTimestamps(data, (*transferRecipient)(r))
+}
+
 // BulkTransfer represents a Paystack bulk transfer
 // You need to disable the Transfers OTP requirement to use this endpoint
 type BulkTransfer struct {
@@ -69,34 +85,47 @@ type BulkTransfer struct {
 }
 
 // TransferList is a list object for transfers.
-type TransferList struct {
-	Meta   ListMeta
-	Values []Transfer `json:"data,omitempty"`
+type TransferList = List[Transfer]
+
+// TransferListParams filters a list of transfers. All fields are optional.
+type TransferListParams struct {
+	ListParams
+	Recipient int       `json:"recipient,omitempty"` // Transfer recipient ID
+	Status    string    `json:"status,omitempty"`
+	From      time.Time `json:"from,omitempty"`
+	To        time.Time `json:"to,omitempty"`
 }
 
 // TransferRecipientList is a list object for transfer recipient.
-type TransferRecipientList struct {
-	Meta   ListMeta
-	Values []TransferRecipient `json:"data,omitempty"`
-}
+type TransferRecipientList = List[TransferRecipient]
 
 // Initiate initiates a new transfer
 // For more details see https://developers.paystack.co/v1.0/reference#initiate-transfer
 func (s *TransferService) Initiate(req *TransferRequest) (*Transfer, error) {
+	return s.InitiateContext(context.Background(), req)
+}
+
+// InitiateContext is like Initiate but uses ctx for the request.
+func (s *TransferService) InitiateContext(ctx context.Context, req *TransferRequest) (*Transfer, error) {
 	transfer := &Transfer{}
-	err := s.client.Call("POST", "/transfer", req, transfer)
+	err := s.client.call(ctx, "Transfer.Initiate", "POST", "/transfer", req, transfer)
 	return transfer, err
 }
 
 // Finalize completes a transfer request
 // For more details see https://developers.paystack.co/v1.0/reference#finalize-transfer
 func (s *TransferService) Finalize(code, otp string) (Response, error) {
+	return s.FinalizeContext(context.Background(), code, otp)
+}
+
+// FinalizeContext is like Finalize but uses ctx for the request.
+func (s *TransferService) FinalizeContext(ctx context.Context, code, otp string) (Response, error) {
 	u := fmt.Sprintf("/transfer/finalize_transfer")
 	req := url.Values{}
 	req.Add("transfer_code", code)
 	req.Add("otp", otp)
 	resp := Response{}
-	err := s.client.Call("POST", u, req, &resp)
+	err := s.client.call(ctx, "Transfer.Finalize", "POST", u, req, &resp)
 	return resp, err
 }
 
@@ -104,44 +133,113 @@ func (s *TransferService) Finalize(code, otp string) (Response, error) {
 // You need to disable the Transfers OTP requirement to use this endpoint
 // For more details see https://developers.paystack.co/v1.0/reference#initiate-bulk-transfer
 func (s *TransferService) MakeBulkTransfer(req *BulkTransfer) (Response, error) {
+	return s.MakeBulkTransferContext(context.Background(), req)
+}
+
+// MakeBulkTransferContext is like MakeBulkTransfer but uses ctx for the request.
+func (s *TransferService) MakeBulkTransferContext(ctx context.Context, req *BulkTransfer) (Response, error) {
 	u := fmt.Sprintf("/transfer")
 	resp := Response{}
-	err := s.client.Call("POST", u, req, &resp)
+	err := s.client.call(ctx, "Transfer.MakeBulkTransfer", "POST", u, req, &resp)
 	return resp, err
 }
 
 // Get returns the details of a transfer.
 // For more details see https://developers.paystack.co/v1.0/reference#fetch-transfer
 func (s *TransferService) Get(idCode string) (*Transfer, error) {
+	return s.GetContext(context.Background(), idCode)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (s *TransferService) GetContext(ctx context.Context, idCode string) (*Transfer, error) {
 	u := fmt.Sprintf("/transfer/%s", idCode)
 	transfer := &Transfer{}
-	err := s.client.Call("GET", u, nil, transfer)
+	err := s.client.call(ctx, "Transfer.Get", "GET", u, nil, transfer)
+	return transfer, err
+}
+
+// Verify returns the details of the transfer with the given reference.
+// For more details see https://paystack.com/docs/api/transfer/#verify
+func (s *TransferService) Verify(reference string) (*Transfer, error) {
+	return s.VerifyContext(context.Background(), reference)
+}
+
+// VerifyContext is like Verify but uses ctx for the request.
+func (s *TransferService) VerifyContext(ctx context.Context, reference string) (*Transfer, error) {
+	u := fmt.Sprintf("/transfer/verify/%s", reference)
+	transfer := &Transfer{}
+	err := s.client.call(ctx, "Transfer.Verify", "GET", u, nil, transfer)
 	return transfer, err
 }
 
 // List returns a list of transfers.
 // For more details see https://developers.paystack.co/v1.0/reference#list-transfers
 func (s *TransferService) List() (*TransferList, error) {
-	return s.ListN(10, 0)
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx for the request.
+func (s *TransferService) ListContext(ctx context.Context) (*TransferList, error) {
+	return s.ListNContext(ctx, 10, 1)
 }
 
 // ListN returns a list of transfers
 // For more details see https://developers.paystack.co/v1.0/reference#list-transfers
 func (s *TransferService) ListN(count, offset int) (*TransferList, error) {
+	return s.ListNContext(context.Background(), count, offset)
+}
+
+// ListNContext is like ListN but uses ctx for the request.
+func (s *TransferService) ListNContext(ctx context.Context, count, offset int) (*TransferList, error) {
 	u := paginateURL("/transfer", count, offset)
 	transfers := &TransferList{}
-	err := s.client.Call("GET", u, nil, transfers)
+	err := s.client.call(ctx, "Transfer.ListN", "GET", u, nil, transfers)
+	return transfers, err
+}
+
+// Iter returns an iterator over all transfers, fetching perPage of them at a time.
+func (s *TransferService) Iter(ctx context.Context, perPage int) *Iterator[Transfer] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[Transfer], error) {
+		return s.ListNContext(ctx, perPage, page)
+	})
+}
+
+// ListWithParams returns a list of transfers matching params
+// For more details see https://paystack.com/docs/api/transfer/#list
+func (s *TransferService) ListWithParams(params *TransferListParams) (*TransferList, error) {
+	return s.ListWithParamsContext(context.Background(), params)
+}
+
+// ListWithParamsContext is like ListWithParams but uses ctx for the request.
+func (s *TransferService) ListWithParamsContext(ctx context.Context, params *TransferListParams) (*TransferList, error) {
+	u := filterURL("/transfer", params)
+	transfers := &TransferList{}
+	err := s.client.call(ctx, "Transfer.ListWithParams", "GET", u, nil, transfers)
 	return transfers, err
 }
 
+// IterWithParams returns an iterator over all transfers matching params,
+// fetching params.PerPage of them at a time. params.Page is ignored.
+func (s *TransferService) IterWithParams(ctx context.Context, params TransferListParams) *Iterator[Transfer] {
+	return newIterator(ctx, params.PerPage, func(ctx context.Context, perPage, page int) (*List[Transfer], error) {
+		params.ListParams = ListParams{PerPage: perPage, Page: page}
+		return s.ListWithParamsContext(ctx, &params)
+	})
+}
+
 // ResendOTP generates a new OTP and sends to customer in the event they are having trouble receiving one.
 // For more details see https://developers.paystack.co/v1.0/reference#resend-otp-for-transfer
 func (s *TransferService) ResendOTP(transferCode, reason string) (Response, error) {
+	return s.ResendOTPContext(context.Background(), transferCode, reason)
+}
+
+// ResendOTPContext is like ResendOTP but uses ctx for the request.
+func (s *TransferService) ResendOTPContext(ctx context.Context, transferCode, reason string) (Response, error) {
 	data := url.Values{}
 	data.Add("transfer_code", transferCode)
 	data.Add("reason", reason)
 	resp := Response{}
-	err := s.client.Call("POST", "/transfer/resend_otp", data, &resp)
+	err := s.client.call(ctx, "Transfer.ResendOTP", "POST", "/transfer/resend_otp", data, &resp)
 	return resp, err
 }
 
@@ -150,8 +248,13 @@ func (s *TransferService) ResendOTP(transferCode, reason string) (Response, erro
 // transfers programmatically, this endpoint helps turn OTP requirement back on.
 // No arguments required.
 func (s *TransferService) EnableOTP() (Response, error) {
+	return s.EnableOTPContext(context.Background())
+}
+
+// EnableOTPContext is like EnableOTP but uses ctx for the request.
+func (s *TransferService) EnableOTPContext(ctx context.Context) (Response, error) {
 	resp := Response{}
-	err := s.client.Call("POST", "/transfer/enable_otp", nil, &resp)
+	err := s.client.call(ctx, "Transfer.EnableOTP", "POST", "/transfer/enable_otp", nil, &resp)
 	return resp, err
 }
 
@@ -160,40 +263,72 @@ func (s *TransferService) EnableOTP() (Response, error) {
 // programmatically without use of OTPs, this endpoint helps disable that….
 // with an OTP. No arguments required. You will get an OTP.
 func (s *TransferService) DisableOTP() (Response, error) {
+	return s.DisableOTPContext(context.Background())
+}
+
+// DisableOTPContext is like DisableOTP but uses ctx for the request.
+func (s *TransferService) DisableOTPContext(ctx context.Context) (Response, error) {
 	resp := Response{}
-	err := s.client.Call("POST", "/transfer/disable_otp", nil, &resp)
+	err := s.client.call(ctx, "Transfer.DisableOTP", "POST", "/transfer/disable_otp", nil, &resp)
 	return resp, err
 }
 
 // FinalizeOTPDisable finalizes disabling of OTP requirement for Transfers
 // For more details see https://developers.paystack.co/v1.0/reference#finalize-disabling-of-otp-requirement-for-transfers
 func (s *TransferService) FinalizeOTPDisable(otp string) (Response, error) {
+	return s.FinalizeOTPDisableContext(context.Background(), otp)
+}
+
+// FinalizeOTPDisableContext is like FinalizeOTPDisable but uses ctx for the request.
+func (s *TransferService) FinalizeOTPDisableContext(ctx context.Context, otp string) (Response, error) {
 	data := url.Values{}
 	data.Add("otp", otp)
 	resp := Response{}
-	err := s.client.Call("POST", "/transfer/disable_otp_finalize", data, &resp)
+	err := s.client.call(ctx, "Transfer.FinalizeOTPDisable", "POST", "/transfer/disable_otp_finalize", data, &resp)
 	return resp, err
 }
 
 // CreateRecipient creates a new transfer recipient
 // For more details see https://developers.paystack.co/v1.0/reference#create-transferrecipient
 func (s *TransferService) CreateRecipient(recipient *TransferRecipient) (*TransferRecipient, error) {
+	return s.CreateRecipientContext(context.Background(), recipient)
+}
+
+// CreateRecipientContext is like CreateRecipient but uses ctx for the request.
+func (s *TransferService) CreateRecipientContext(ctx context.Context, recipient *TransferRecipient) (*TransferRecipient, error) {
 	recipient1 := &TransferRecipient{}
-	err := s.client.Call("POST", "/transferrecipient", recipient, recipient1)
+	err := s.client.call(ctx, "Transfer.CreateRecipient", "POST", "/transferrecipient", recipient, recipient1)
 	return recipient1, err
 }
 
 // ListRecipients returns a list of transfer recipients.
 // For more details see https://developers.paystack.co/v1.0/reference#list-transferrecipients
 func (s *TransferService) ListRecipients() (*TransferRecipientList, error) {
-	return s.ListRecipientsN(10, 1)
+	return s.ListRecipientsContext(context.Background())
+}
+
+// ListRecipientsContext is like ListRecipients but uses ctx for the request.
+func (s *TransferService) ListRecipientsContext(ctx context.Context) (*TransferRecipientList, error) {
+	return s.ListRecipientsNContext(ctx, 10, 1)
 }
 
 // ListRecipientsN returns a list of transfer recipients
 // For more details see https://developers.paystack.co/v1.0/reference#list-transferrecipients
 func (s *TransferService) ListRecipientsN(count, offset int) (*TransferRecipientList, error) {
+	return s.ListRecipientsNContext(context.Background(), count, offset)
+}
+
+// ListRecipientsNContext is like ListRecipientsN but uses ctx for the request.
+func (s *TransferService) ListRecipientsNContext(ctx context.Context, count, offset int) (*TransferRecipientList, error) {
 	u := paginateURL("/transferrecipient", count, offset)
 	resp := &TransferRecipientList{}
-	err := s.client.Call("GET", u, nil, &resp)
+	err := s.client.call(ctx, "Transfer.ListRecipientsN", "GET", u, nil, resp)
 	return resp, err
 }
+
+// IterRecipients returns an iterator over all transfer recipients, fetching perPage of them at a time.
+func (s *TransferService) IterRecipients(ctx context.Context, perPage int) *Iterator[TransferRecipient] {
+	return newIterator(ctx, perPage, func(ctx context.Context, perPage, page int) (*List[TransferRecipient], error) {
+		return s.ListRecipientsNContext(ctx, perPage, page)
+	})
+}
@@ -5,6 +5,8 @@ import (
 )
 
 func TestInitiateTransfer(t *testing.T) {
+	useClient(t)
+
 	c.Transfer.EnableOTP()
 
 	recipient := &TransferRecipient{
@@ -22,7 +24,7 @@ func TestInitiateTransfer(t *testing.T) {
 	req := &TransferRequest{
 		Source:    "balance",
 		Reason:    "Delivery pickup",
-		Amount:    300,
+		Amount:    NewMoney(300, "NGN"),
 		Recipient: recipient1.RecipientCode,
 	}
 
@@ -83,6 +85,8 @@ func TestBulkTransfer(t *testing.T) {
 */
 
 func TestTransferList(t *testing.T) {
+	useClient(t)
+
 	// retrieve the transfer list
 	transfers, err := c.Transfer.List()
 	if err != nil {
@@ -91,6 +95,8 @@ func TestTransferList(t *testing.T) {
 }
 
 func TestTransferRecipientList(t *testing.T) {
+	useClient(t)
+
 	//fmt.Println("createDemoRecipients <<<<<<<")
 	//_, err := createDemoRecipients()
 
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store remembers the events a Handler has handled, so that the events
+// Paystack sends again are handled once. Implementations backed by a
+// shared database deduplicate across processes.
+type Store interface {
+	// Claim records key, reporting false if it is recorded already.
+	Claim(ctx context.Context, key string) (bool, error)
+	// Release forgets key, so that the event is handled when it is sent
+	// again. It is called when handling the event failed.
+	Release(ctx context.Context, key string) error
+}
+
+// MemoryStore is a Store keeping keys in memory for a fixed time.
+type MemoryStore struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]time.Time // expiry by key
+	lastSweep time.Time
+}
+
+// NewMemoryStore returns a store forgetting keys after ttl. Paystack
+// retries events for up to 72 hours.
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{ttl: ttl, keys: make(map[string]time.Time)}
+}
+
+// Claim records key for the TTL of the store.
+func (s *MemoryStore) Claim(ctx context.Context, key string) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) >= s.ttl {
+		for k, exp := range s.keys {
+			if !now.Before(exp) {
+				delete(s.keys, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	if exp, ok := s.keys[key]; ok && now.Before(exp) {
+		return false, nil
+	}
+	s.keys[key] = now.Add(s.ttl)
+	return true, nil
+}
+
+// Release forgets key.
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+	return nil
+}
+
+// resourceFields are the fields identifying the resource of an event, in
+// order of preference
+var resourceFields = []string{
+	"reference", "id", "refund_reference", "transaction_reference",
+	"transfer_code", "subscription_code", "invoice_code", "customer_code", "customer_id",
+}
+
+// refundFields are the fields identifying a refund. Its transaction
+// reference does not: a transaction may be refunded in several parts.
+var refundFields = []string{"id", "refund_reference"}
+
+// EventKey returns the key deduplicating ev: its type and the reference
+// or ID of its resource, such as "transfer.success:1jhbs3ozmen0k7y5efmw".
+// Events whose resource cannot be told, such as refunds without an ID or
+// refund reference, are keyed on a hash of their data.
+func EventKey(ev *Event) string {
+	if id := resourceID(ev); id != "" {
+		return ev.Type + ":" + id
+	}
+	sum := sha256.Sum256(ev.Raw)
+	return ev.Type + ":sha256:" + hex.EncodeToString(sum[:])
+}
+
+// resourceID returns the reference or ID of the resource of ev, or ""
+func resourceID(ev *Event) string {
+	var data map[string]interface{}
+	if err := json.Unmarshal(ev.Raw, &data); err != nil {
+		return ""
+	}
+	if strings.HasPrefix(ev.Type, "refund.") {
+		return firstField(data, refundFields)
+	}
+	if id := firstField(data, resourceFields); id != "" {
+		return id
+	}
+	// dedicated account assignments are about an account or a customer
+	for _, key := range []string{"dedicated_account", "customer"} {
+		if nested, ok := data[key].(map[string]interface{}); ok {
+			if id := firstField(nested, resourceFields); id != "" {
+				return key + ":" + id
+			}
+		}
+	}
+	return ""
+}
+
+// firstField returns the first of fields set in data, or ""
+func firstField(data map[string]interface{}, fields []string) string {
+	for _, f := range fields {
+		switch v := data[f].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return fmt.Sprintf("%.0f", v)
+		}
+	}
+	return ""
+}
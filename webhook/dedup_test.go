@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(20 * time.Millisecond)
+
+	if ok, _ := s.Claim(ctx, "a"); !ok {
+		t.Fatal("expected the first claim to succeed")
+	}
+	if ok, _ := s.Claim(ctx, "a"); ok {
+		t.Error("expected a second claim to fail")
+	}
+	s.Release(ctx, "a")
+	if ok, _ := s.Claim(ctx, "a"); !ok {
+		t.Error("expected a claim after release to succeed")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if ok, _ := s.Claim(ctx, "a"); !ok {
+		t.Error("expected a claim after the TTL to succeed")
+	}
+}
+
+func TestEventKey(t *testing.T) {
+	for body, want := range map[string]string{
+		`{"event":"charge.success","data":{"id":1,"reference":"ref-1"}}`:                                        "charge.success:ref-1",
+		`{"event":"charge.dispute.create","data":{"id":358950}}`:                                                "charge.dispute.create:358950",
+		`{"event":"refund.processed","data":{"refund_reference":"R1","transaction_reference":"T1"}}`:            "refund.processed:R1",
+		`{"event":"refund.pending","data":{"id":3,"refund_reference":null,"transaction_reference":"T1"}}`:       "refund.pending:3",
+		`{"event":"subscription.disable","data":{"subscription_code":"SUB_1"}}`:                                 "subscription.disable:SUB_1",
+		`{"event":"dedicatedaccount.assign.success","data":{"customer":{"id":7},"dedicated_account":{"id":9}}}`: "dedicatedaccount.assign.success:dedicated_account:9",
+	} {
+		ev, err := Parse([]byte(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := EventKey(ev); got != want {
+			t.Errorf("%s: got %q, want %q", body, got, want)
+		}
+	}
+
+	for _, pair := range [][2]string{
+		{`{"event":"paymentrequest.pending","data":{"amount":100}}`, `{"event":"paymentrequest.pending","data":{"amount":200}}`},
+		// partial refunds of one transaction
+		{`{"event":"refund.pending","data":{"transaction_reference":"T1","amount":100}}`, `{"event":"refund.pending","data":{"transaction_reference":"T1","amount":200}}`},
+	} {
+		ev, _ := Parse([]byte(pair[0]))
+		other, _ := Parse([]byte(pair[1]))
+		if EventKey(ev) == EventKey(other) {
+			t.Errorf("%s: expected events without a resource ID to be keyed on their data", pair[0])
+		}
+	}
+}
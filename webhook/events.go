@@ -0,0 +1,233 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/rpip/paystack-go"
+)
+
+// Event types, with the type of the Data of their events.
+const (
+	// *paystack.Transaction
+	EventChargeSuccess = "charge.success"
+
+	// *Transfer
+	EventTransferSuccess  = "transfer.success"
+	EventTransferFailed   = "transfer.failed"
+	EventTransferReversed = "transfer.reversed"
+
+	// *Subscription
+	EventSubscriptionCreate   = "subscription.create"
+	EventSubscriptionDisable  = "subscription.disable"
+	EventSubscriptionNotRenew = "subscription.not_renew"
+
+	// *Invoice
+	EventInvoiceCreate        = "invoice.create"
+	EventInvoiceUpdate        = "invoice.update"
+	EventInvoicePaymentFailed = "invoice.payment_failed"
+
+	// *Refund
+	EventRefundPending    = "refund.pending"
+	EventRefundProcessing = "refund.processing"
+	EventRefundProcessed  = "refund.processed"
+	EventRefundFailed     = "refund.failed"
+
+	// *Dispute
+	EventDisputeCreate  = "charge.dispute.create"
+	EventDisputeRemind  = "charge.dispute.remind"
+	EventDisputeResolve = "charge.dispute.resolve"
+
+	// *DedicatedAccountAssignment
+	EventDedicatedAccountAssignSuccess = "dedicatedaccount.assign.success"
+	EventDedicatedAccountAssignFailed  = "dedicatedaccount.assign.failed"
+
+	// *CustomerIdentification
+	EventCustomerIdentificationSuccess = "customeridentification.success"
+	EventCustomerIdentificationFailed  = "customeridentification.failed"
+)
+
+// newData returns a pointer to decode the data of events of type typ into
+func newData(typ string) interface{} {
+	switch {
+	case typ == EventChargeSuccess:
+		return &paystack.Transaction{}
+	case typ == EventTransferSuccess, typ == EventTransferFailed, typ == EventTransferReversed:
+		return &Transfer{}
+	case typ == EventSubscriptionCreate, typ == EventSubscriptionDisable, typ == EventSubscriptionNotRenew:
+		return &Subscription{}
+	case strings.HasPrefix(typ, "invoice."):
+		return &Invoice{}
+	case strings.HasPrefix(typ, "refund."):
+		return &Refund{}
+	case strings.HasPrefix(typ, "charge.dispute."):
+		return &Dispute{}
+	case strings.HasPrefix(typ, "dedicatedaccount.assign."):
+		return &DedicatedAccountAssignment{}
+	case strings.HasPrefix(typ, "customeridentification."):
+		return &CustomerIdentification{}
+	}
+	return &map[string]interface{}{}
+}
+
+// Integration is the integration a resource belongs to. Events carry
+// either its ID or an object.
+type Integration struct {
+	ID           int    `json:"id,omitempty"`
+	IsLive       bool   `json:"is_live,omitempty"`
+	BusinessName string `json:"business_name,omitempty"`
+}
+
+// UnmarshalJSON decodes an integration ID or object.
+func (i *Integration) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] != '{' {
+		return json.Unmarshal(data, &i.ID)
+	}
+	type integration Integration
+	return json.Unmarshal(data, (*integration)(i))
+}
+
+// Transfer is the data of transfer events.
+type Transfer struct {
+	paystack.Transfer
+	Integration     Integration      `json:"integration,omitempty"`
+	FeeCharged      paystack.Money   `json:"fee_charged,omitzero"`
+	GatewayResponse string           `json:"gateway_response,omitempty"`
+	Session         *TransferSession `json:"session,omitempty"`
+}
+
+// UnmarshalJSON decodes the transfer and the fields transfer events add.
+func (t *Transfer) UnmarshalJSON(data []byte) error {
+	return unmarshalEmbedded(data, &t.Transfer, map[string]interface{}{
+		"integration":      &t.Integration,
+		"fee_charged":      &t.FeeCharged,
+		"gateway_response": &t.GatewayResponse,
+		"session":          &t.Session,
+	})
+}
+
+// TransferSession is the session of the transfer at the bank.
+type TransferSession struct {
+	Provider string `json:"provider,omitempty"`
+	ID       string `json:"id,omitempty"`
+}
+
+// Subscription is the data of subscription events.
+type Subscription struct {
+	paystack.Subscription
+	Integration Integration `json:"integration,omitempty"`
+}
+
+// UnmarshalJSON decodes the subscription and the fields subscription
+// events add.
+func (s *Subscription) UnmarshalJSON(data []byte) error {
+	return unmarshalEmbedded(data, &s.Subscription, map[string]interface{}{
+		"integration": &s.Integration,
+	})
+}
+
+// Invoice is the data of invoice events, about a payment of a
+// subscription.
+type Invoice struct {
+	Domain        string                 `json:"domain,omitempty"`
+	InvoiceCode   string                 `json:"invoice_code,omitempty"`
+	Amount        paystack.Money         `json:"amount,omitzero"`
+	PeriodStart   paystack.Timestamp     `json:"period_start,omitzero"`
+	PeriodEnd     paystack.Timestamp     `json:"period_end,omitzero"`
+	Status        string                 `json:"status,omitempty"`
+	Paid          bool                   `json:"paid,omitempty"`
+	PaidAt        paystack.Timestamp     `json:"paid_at,omitzero"`
+	Description   string                 `json:"description,omitempty"`
+	Authorization paystack.Authorization `json:"authorization,omitempty"`
+	Subscription  Subscription           `json:"subscription,omitempty"`
+	Customer      paystack.Customer      `json:"customer,omitempty"`
+	Transaction   paystack.Transaction   `json:"transaction,omitempty"`
+	CreatedAt     paystack.Timestamp     `json:"created_at,omitzero"`
+}
+
+// Refund is the data of refund events.
+type Refund struct {
+	Status               string            `json:"status,omitempty"`
+	TransactionReference string            `json:"transaction_reference,omitempty"`
+	RefundReference      string            `json:"refund_reference,omitempty"`
+	Amount               paystack.Money    `json:"amount,omitzero"`
+	Currency             string            `json:"currency,omitempty"`
+	Processor            string            `json:"processor,omitempty"`
+	Customer             paystack.Customer `json:"customer,omitempty"`
+	Integration          Integration       `json:"integration,omitempty"`
+	Domain               string            `json:"domain,omitempty"`
+}
+
+// Dispute is the data of dispute events.
+type Dispute struct {
+	paystack.Dispute
+	Integration Integration       `json:"integration,omitempty"`
+	Customer    paystack.Customer `json:"customer,omitempty"`
+}
+
+// UnmarshalJSON decodes the dispute and the fields dispute events add.
+func (d *Dispute) UnmarshalJSON(data []byte) error {
+	return unmarshalEmbedded(data, &d.Dispute, map[string]interface{}{
+		"integration": &d.Integration,
+		"customer":    &d.Customer,
+	})
+}
+
+// DedicatedAccountAssignment is the data of dedicated account assignment
+// events, sent once an assignment started with
+// DedicatedVirtualAccountService.Assign completes. DedicatedAccount is nil
+// when the assignment failed.
+type DedicatedAccountAssignment struct {
+	Customer         paystack.Customer                 `json:"customer,omitempty"`
+	DedicatedAccount *paystack.DedicatedVirtualAccount `json:"dedicated_account,omitempty"`
+	Identification   Identification                    `json:"identification,omitempty"`
+}
+
+// Identification is the result of validating the identity of a customer.
+// Identifiers are masked.
+type Identification struct {
+	Status        string `json:"status,omitempty"`
+	Country       string `json:"country,omitempty"`
+	Type          string `json:"type,omitempty"`
+	BVN           string `json:"bvn,omitempty"`
+	AccountNumber string `json:"account_number,omitempty"`
+	BankCode      string `json:"bank_code,omitempty"`
+}
+
+// CustomerIdentification is the data of customer identification events.
+// Reason says why the identification failed.
+type CustomerIdentification struct {
+	CustomerID     json.Number    `json:"customer_id,omitempty"`
+	CustomerCode   string         `json:"customer_code,omitempty"`
+	Email          string         `json:"email,omitempty"`
+	Identification Identification `json:"identification,omitempty"`
+	Reason         string         `json:"reason,omitempty"`
+}
+
+// unmarshalEmbedded decodes an event type embedding a paystack resource.
+// The fields the event type adds, given by key, are decoded first and left
+// out of the data then decoded into resource, whose fields they shadow.
+func unmarshalEmbedded(data []byte, resource json.Unmarshaler, fields map[string]interface{}) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	for key, field := range fields {
+		if raw, ok := m[key]; ok {
+			if err := json.Unmarshal(raw, field); err != nil {
+				return err
+			}
+			delete(m, key)
+		}
+	}
+	rest, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return resource.UnmarshalJSON(rest)
+}
@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+)
+
+// MaxBodyBytes is the largest event body a Handler accepts.
+const MaxBodyBytes = 1 << 20
+
+// HandlerFunc handles an event. Returning an error answers Paystack with
+// a server error, so that the event is sent again later.
+type HandlerFunc func(ctx context.Context, ev *Event) error
+
+// Handler is an http.Handler receiving Paystack events. It checks their
+// signature and calls the callbacks registered for their type.
+//
+// Events are acknowledged with 200 OK once handled, or when no callback
+// is registered for them. Events with a bad signature are rejected with
+// 401 Unauthorized, and events from sources that are not allowed with 403
+// Forbidden.
+//
+// The fields of a Handler must not be changed once it serves events.
+type Handler struct {
+	// Store deduplicates events: an event whose key is claimed already is
+	// acknowledged without calling its callback. If nil, every event is
+	// handled.
+	Store Store
+	// Key returns the key deduplicating an event. If nil, EventKey is used.
+	Key func(ev *Event) string
+
+	// OutOfOrder is how events arriving after an event superseding them
+	// are treated, such as transfer.success after transfer.reversed for
+	// the same transfer. The latest event of each resource is remembered
+	// in memory for 72 hours, not in Store, so only events reaching the
+	// same process are ordered: behind a load balancer, an out-of-order
+	// event handled by another process is delivered as in order.
+	OutOfOrder OutOfOrderPolicy
+
+	// AllowedSources restricts the addresses events are accepted from,
+	// e.g. to PaystackSources. If nil, events are accepted from anywhere.
+	AllowedSources []netip.Prefix
+	// ClientIP returns the address a request comes from. If nil,
+	// RemoteAddr is used.
+	ClientIP func(r *http.Request) string
+
+	secretKey string
+	sequencer sequencer
+
+	mu        sync.RWMutex
+	callbacks map[string]HandlerFunc
+}
+
+// NewHandler returns a handler of the events signed with secretKey.
+func NewHandler(secretKey string) *Handler {
+	return &Handler{
+		secretKey: secretKey,
+		callbacks: make(map[string]HandlerFunc),
+	}
+}
+
+// On registers fn to handle events of type eventType, replacing the
+// callback registered before. A type ending in ".*", such as "refund.*",
+// matches every type with its prefix, and "*" matches every type. The
+// most specific callback registered for an event is called.
+func (h *Handler) On(eventType string, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks[eventType] = fn
+}
+
+// callback returns the callback for events of type typ, or nil
+func (h *Handler) callback(typ string) HandlerFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if fn, ok := h.callbacks[typ]; ok {
+		return fn
+	}
+	// charge.dispute.create tries charge.dispute.*, then charge.*
+	for prefix := typ; ; {
+		i := strings.LastIndex(prefix, ".")
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+		if fn, ok := h.callbacks[prefix+".*"]; ok {
+			return fn
+		}
+	}
+	return h.callbacks["*"]
+}
+
+// ServeHTTP receives an event.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.allowedSource(r) {
+		http.Error(w, "source not allowed", http.StatusForbidden)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "event too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "reading event", http.StatusBadRequest)
+		return
+	}
+
+	ev, err := ConstructEvent(h.secretKey, body, r.Header.Get(SignatureHeader))
+	switch {
+	case errors.Is(err, ErrMissingSignature), errors.Is(err, ErrInvalidSignature):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.handle(r.Context(), ev); err != nil {
+		http.Error(w, "handling event failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handle calls the callback of ev, unless ev is a duplicate or is skipped
+// for being out of order
+func (h *Handler) handle(ctx context.Context, ev *Event) error {
+	if h.Store != nil {
+		key := h.key(ev)
+		claimed, err := h.Store.Claim(ctx, key)
+		if err != nil || !claimed {
+			return err
+		}
+		if err := h.dispatch(ctx, ev); err != nil {
+			h.Store.Release(ctx, key)
+			return err
+		}
+		return nil
+	}
+	return h.dispatch(ctx, ev)
+}
+
+func (h *Handler) dispatch(ctx context.Context, ev *Event) error {
+	ev.SupersededBy = h.sequencer.supersededBy(ev)
+	if ev.OutOfOrder() && h.OutOfOrder == SkipOutOfOrder {
+		return nil
+	}
+	if fn := h.callback(ev.Type); fn != nil {
+		if err := fn(ctx, ev); err != nil {
+			return err
+		}
+	}
+	h.sequencer.handled(ev)
+	return nil
+}
+
+func (h *Handler) key(ev *Event) string {
+	if h.Key != nil {
+		return h.Key(ev)
+	}
+	return EventKey(ev)
+}
@@ -0,0 +1,215 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// deliver sends body to h as Paystack would, signed with key
+func deliver(h http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(SignatureHeader, Sign(key, []byte(body)))
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandlerDispatch(t *testing.T) {
+	h := NewHandler(testKey)
+	var got []string
+	record := func(name string) HandlerFunc {
+		return func(ctx context.Context, ev *Event) error {
+			got = append(got, name+" "+ev.Type)
+			return nil
+		}
+	}
+	h.On(EventDisputeCreate, record("exact"))
+	h.On("charge.dispute.*", record("dispute"))
+	h.On("charge.*", record("charge"))
+	h.On("*", record("any"))
+
+	for _, typ := range []string{"charge.dispute.create", "charge.dispute.resolve", "charge.success", "transfer.success"} {
+		if w := deliver(h, testKey, `{"event":"`+typ+`","data":{}}`); w.Code != http.StatusOK {
+			t.Errorf("%s: got %d, want 200", typ, w.Code)
+		}
+	}
+	want := []string{
+		"exact charge.dispute.create",
+		"dispute charge.dispute.resolve",
+		"charge charge.success",
+		"any transfer.success",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got calls\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestHandlerResponses(t *testing.T) {
+	h := NewHandler(testKey)
+	h.On(EventTransferFailed, func(ctx context.Context, ev *Event) error {
+		return errors.New("database is down")
+	})
+	body := `{"event":"transfer.failed","data":{"transfer_code":"TRF_1"}}`
+
+	if w := deliver(h, "", body); w.Code != http.StatusUnauthorized {
+		t.Errorf("unsigned: got %d, want 401", w.Code)
+	}
+	if w := deliver(h, "sk_test_other", body); w.Code != http.StatusUnauthorized {
+		t.Errorf("signed with another key: got %d, want 401", w.Code)
+	}
+	if w := deliver(h, testKey, `{"data":{}}`); w.Code != http.StatusBadRequest {
+		t.Errorf("no event type: got %d, want 400", w.Code)
+	}
+	if w := deliver(h, testKey, body); w.Code != http.StatusInternalServerError {
+		t.Errorf("failing callback: got %d, want 500 so the event is retried", w.Code)
+	}
+	if w := deliver(h, testKey, `{"event":"subscription.disable","data":{}}`); w.Code != http.StatusOK {
+		t.Errorf("no callback: got %d, want 200", w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: got %d, want 405", w.Code)
+	}
+	if w := deliver(h, testKey, `{"event":"charge.success","data":"`+strings.Repeat("x", MaxBodyBytes)+`"}`); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body: got %d, want 413", w.Code)
+	}
+}
+
+func TestHandlerDeduplicates(t *testing.T) {
+	h := NewHandler(testKey)
+	h.Store = NewMemoryStore(time.Hour)
+	calls, fail := 0, true
+	h.On(EventChargeSuccess, func(ctx context.Context, ev *Event) error {
+		calls++
+		if fail {
+			return errors.New("temporarily down")
+		}
+		return nil
+	})
+	body := `{"event":"charge.success","data":{"reference":"order-1"}}`
+
+	if w := deliver(h, testKey, body); w.Code != http.StatusInternalServerError {
+		t.Fatalf("got %d, want 500", w.Code)
+	}
+	// the failed event was released, so the retry is handled
+	fail = false
+	for i := 0; i < 3; i++ {
+		if w := deliver(h, testKey, body); w.Code != http.StatusOK {
+			t.Fatalf("delivery %d: got %d, want 200", i, w.Code)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, want 2: the failure and one success", calls)
+	}
+	deliver(h, testKey, `{"event":"charge.success","data":{"reference":"order-2"}}`)
+	if calls != 3 {
+		t.Errorf("got %d calls, want another for a new reference", calls)
+	}
+}
+
+func TestHandlerOutOfOrder(t *testing.T) {
+	reversed := `{"event":"transfer.reversed","data":{"reference":"trf-1","transfer_code":"TRF_1"}}`
+	success := `{"event":"transfer.success","data":{"reference":"trf-1","transfer_code":"TRF_1"}}`
+
+	for _, policy := range []OutOfOrderPolicy{DeliverOutOfOrder, SkipOutOfOrder} {
+		h := NewHandler(testKey)
+		h.OutOfOrder = policy
+		var got []string
+		h.On("transfer.*", func(ctx context.Context, ev *Event) error {
+			got = append(got, ev.Type+" "+ev.SupersededBy)
+			return nil
+		})
+		deliver(h, testKey, reversed)
+		if w := deliver(h, testKey, success); w.Code != http.StatusOK {
+			t.Errorf("got %d, want out-of-order events acknowledged", w.Code)
+		}
+		// another transfer is not affected
+		deliver(h, testKey, `{"event":"transfer.success","data":{"reference":"trf-2"}}`)
+
+		want := []string{"transfer.reversed ", "transfer.success transfer.reversed", "transfer.success "}
+		if policy == SkipOutOfOrder {
+			want = []string{"transfer.reversed ", "transfer.success "}
+		}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("policy %d: got %q, want %q", policy, got, want)
+		}
+	}
+}
+
+func TestHandlerAllowedSources(t *testing.T) {
+	h := NewHandler(testKey)
+	h.AllowedSources = PaystackSources
+	body := `{"event":"charge.success","data":{}}`
+
+	send := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(SignatureHeader, Sign(testKey, []byte(body)))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := send("52.31.139.75:443"); code != http.StatusOK {
+		t.Errorf("Paystack address: got %d, want 200", code)
+	}
+	if code := send("[::ffff:52.214.14.220]:443"); code != http.StatusOK {
+		t.Errorf("IPv4-mapped Paystack address: got %d, want 200", code)
+	}
+	if code := send("203.0.113.9:443"); code != http.StatusForbidden {
+		t.Errorf("other address: got %d, want 403", code)
+	}
+
+	h.ClientIP = func(r *http.Request) string { return r.Header.Get("X-Real-IP") }
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("X-Real-IP", "52.49.173.169")
+	req.Header.Set(SignatureHeader, Sign(testKey, []byte(body)))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("forwarded Paystack address: got %d, want 200", w.Code)
+	}
+}
+
+func TestHandlerPartialRefunds(t *testing.T) {
+	h := NewHandler(testKey)
+	h.Store = NewMemoryStore(time.Hour)
+	h.OutOfOrder = SkipOutOfOrder
+	var got []string
+	h.On("refund.*", func(ctx context.Context, ev *Event) error {
+		refund := ev.Data.(*Refund)
+		got = append(got, ev.Type+" "+refund.Amount.String()+" "+ev.SupersededBy)
+		return nil
+	})
+
+	// two partial refunds of one transaction, without IDs while pending
+	deliveries := []string{
+		`{"event":"refund.pending","data":{"transaction_reference":"T1","amount":100,"currency":"NGN"}}`,
+		`{"event":"refund.processed","data":{"id":1,"refund_reference":"R1","transaction_reference":"T1","amount":100,"currency":"NGN"}}`,
+		`{"event":"refund.pending","data":{"transaction_reference":"T1","amount":200,"currency":"NGN"}}`,
+		`{"event":"refund.processed","data":{"id":2,"refund_reference":"R2","transaction_reference":"T1","amount":200,"currency":"NGN"}}`,
+		`{"event":"refund.pending","data":{"transaction_reference":"T1","amount":200,"currency":"NGN"}}`,
+	}
+	for _, body := range deliveries {
+		if w := deliver(h, testKey, body); w.Code != http.StatusOK {
+			t.Fatalf("got %d, want 200", w.Code)
+		}
+	}
+	want := []string{
+		"refund.pending ₦1.00 ",
+		"refund.processed ₦1.00 ",
+		"refund.pending ₦2.00 ",
+		"refund.processed ₦2.00 ",
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutOfOrderPolicy is how a Handler treats events arriving after an event
+// that supersedes them, such as transfer.success after transfer.reversed.
+// Superseding events are remembered by the Handler in memory, so the
+// policy only applies to events that reached the same process.
+type OutOfOrderPolicy int
+
+const (
+	// DeliverOutOfOrder calls the callback of out-of-order events, with
+	// Event.SupersededBy set.
+	DeliverOutOfOrder OutOfOrderPolicy = iota
+	// SkipOutOfOrder acknowledges out-of-order events without calling
+	// their callback.
+	SkipOutOfOrder
+)
+
+// stages order the events of a resource: an event is out of order when
+// an event of a later stage was handled before it. Events of the same
+// stage, such as transfer.success and transfer.failed, are not ordered.
+var stages = map[string]int{
+	EventTransferSuccess:  1,
+	EventTransferFailed:   1,
+	EventTransferReversed: 2,
+
+	EventSubscriptionCreate:   1,
+	EventSubscriptionNotRenew: 2,
+	EventSubscriptionDisable:  3,
+
+	EventInvoiceCreate:        1,
+	EventInvoiceUpdate:        2,
+	EventInvoicePaymentFailed: 2,
+
+	EventRefundPending:    1,
+	EventRefundProcessing: 2,
+	EventRefundProcessed:  3,
+	EventRefundFailed:     3,
+
+	EventDisputeCreate:  1,
+	EventDisputeRemind:  2,
+	EventDisputeResolve: 3,
+}
+
+// orderWindow is how long the latest stage of a resource is remembered
+const orderWindow = 72 * time.Hour
+
+// sequencer remembers the latest stage handled for each resource. Its
+// state is local to the process, unlike the keys of a Handler's Store.
+type sequencer struct {
+	mu        sync.Mutex
+	latest    map[string]stage
+	lastSweep time.Time
+}
+
+type stage struct {
+	typ     string
+	n       int
+	expires time.Time
+}
+
+// resource returns the key of the resource of ev and the stage of ev, or
+// "" if ev is not ordered, as when its resource cannot be told
+func resource(ev *Event) (string, int) {
+	n, ok := stages[ev.Type]
+	if !ok {
+		return "", 0
+	}
+	group := ev.Type[:strings.LastIndex(ev.Type, ".")]
+	id := resourceID(ev)
+	if id == "" {
+		return "", 0
+	}
+	return group + ":" + id, n
+}
+
+// supersededBy returns the type of the event handled before ev that
+// supersedes it, or ""
+func (s *sequencer) supersededBy(ev *Event) string {
+	key, n := resource(ev)
+	if key == "" {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if st, ok := s.latest[key]; ok && st.n > n && time.Now().Before(st.expires) {
+		return st.typ
+	}
+	return ""
+}
+
+// handled records that ev was handled
+func (s *sequencer) handled(ev *Event) {
+	key, n := resource(ev)
+	if key == "" {
+		return
+	}
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.latest == nil {
+		s.latest = make(map[string]stage)
+	}
+	if now.Sub(s.lastSweep) >= orderWindow {
+		for k, st := range s.latest {
+			if !now.Before(st.expires) {
+				delete(s.latest, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	if st, ok := s.latest[key]; ok && st.n > n && now.Before(st.expires) {
+		return
+	}
+	s.latest[key] = stage{typ: ev.Type, n: n, expires: now.Add(orderWindow)}
+}
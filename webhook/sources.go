@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+)
+
+// PaystackSources are the addresses Paystack sends webhooks from.
+// For more details see https://paystack.com/docs/payments/webhooks/#ip-whitelisting
+var PaystackSources = []netip.Prefix{
+	netip.MustParsePrefix("52.31.139.75/32"),
+	netip.MustParsePrefix("52.49.173.169/32"),
+	netip.MustParsePrefix("52.214.14.220/32"),
+}
+
+// RemoteAddr returns the address of the peer of r. It is the default
+// source of events; behind a proxy, set Handler.ClientIP to read the
+// address the proxy forwards.
+func RemoteAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// allowedSource reports whether r comes from one of the AllowedSources of h
+func (h *Handler) allowedSource(r *http.Request) bool {
+	if h.AllowedSources == nil {
+		return true
+	}
+	clientIP := h.ClientIP
+	if clientIP == nil {
+		clientIP = RemoteAddr
+	}
+	addr, err := netip.ParseAddr(clientIP(r))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range h.AllowedSources {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+// Package webhook verifies and parses the events Paystack sends to
+// webhook URLs.
+//
+// Paystack signs every event with the secret key of the integration.
+// Handler checks the signature and dispatches events to callbacks:
+//
+//	h := webhook.NewHandler(secretKey)
+//	h.On(webhook.EventChargeSuccess, func(ctx context.Context, ev *webhook.Event) error {
+//		txn := ev.Data.(*paystack.Transaction)
+//		return fulfil(ctx, txn.Reference)
+//	})
+//	http.Handle("/paystack/webhook", h)
+//
+// For more details see https://paystack.com/docs/payments/webhooks
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/rpip/paystack-go"
+)
+
+// SignatureHeader is the header carrying the signature of an event.
+const SignatureHeader = "X-Paystack-Signature"
+
+var (
+	// ErrMissingSignature is returned for events without a signature.
+	ErrMissingSignature = errors.New("webhook: missing signature")
+	// ErrInvalidSignature is returned for events whose signature does not
+	// match their body.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+)
+
+// Sign returns the signature of body with secretKey: the hex encoded
+// HMAC-SHA512 of body. It is useful to send signed events in tests.
+func Sign(secretKey string, body []byte) string {
+	mac := hmac.New(sha512.New, []byte(secretKey))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks signature against the raw body of an event, in
+// constant time.
+func VerifySignature(secretKey string, body []byte, signature string) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha512.New, []byte(secretKey))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Event is an event sent by Paystack.
+type Event struct {
+	// Type is the kind of the event, such as charge.success.
+	Type string `json:"event"`
+
+	// Data is the resource the event is about, decoded to the type given
+	// with the constant of Type, or else to a map[string]interface{}.
+	Data interface{} `json:"-"`
+
+	// Raw is the data of the event as sent.
+	Raw json.RawMessage `json:"data"`
+
+	// SupersededBy is set by a Handler for events arriving after an event
+	// that supersedes them, to the type of that event.
+	SupersededBy string `json:"-"`
+}
+
+// OutOfOrder reports whether ev arrived after an event superseding it.
+func (ev *Event) OutOfOrder() bool {
+	return ev.SupersededBy != ""
+}
+
+// Parse parses an event from its raw body, without checking its signature.
+func Parse(body []byte) (*Event, error) {
+	ev := &Event{}
+	if err := json.Unmarshal(body, ev); err != nil {
+		return nil, fmt.Errorf("webhook: parsing event: %w", err)
+	}
+	if ev.Type == "" {
+		return nil, errors.New("webhook: parsing event: no event type")
+	}
+	data := newData(ev.Type)
+	if len(ev.Raw) > 0 {
+		if err := json.Unmarshal(ev.Raw, data); err != nil {
+			return nil, fmt.Errorf("webhook: parsing %s event: %w", ev.Type, err)
+		}
+	}
+	if m, ok := data.(*map[string]interface{}); ok {
+		ev.Data = *m
+		return ev, nil
+	}
+	paystack.FillCurrencies(data)
+	ev.Data = data
+	return ev, nil
+}
+
+// ConstructEvent checks the signature of an event and parses it.
+func ConstructEvent(secretKey string, body []byte, signature string) (*Event, error) {
+	if err := VerifySignature(secretKey, body, signature); err != nil {
+		return nil, err
+	}
+	return Parse(body)
+}
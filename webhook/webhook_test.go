@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rpip/paystack-go"
+)
+
+const testKey = "sk_test_webhook"
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"event":"charge.success","data":{}}`)
+	sig := Sign(testKey, body)
+	if len(sig) != 128 {
+		t.Fatalf("got a signature of %d characters, want the 128 of a hex SHA-512", len(sig))
+	}
+
+	if err := VerifySignature(testKey, body, sig); err != nil {
+		t.Errorf("valid signature: %v", err)
+	}
+	for name, tc := range map[string]struct {
+		key, sig string
+		body     []byte
+		want     error
+	}{
+		"missing":       {testKey, "", body, ErrMissingSignature},
+		"not hex":       {testKey, "zz", body, ErrInvalidSignature},
+		"other key":     {"sk_test_other", sig, body, ErrInvalidSignature},
+		"modified body": {testKey, sig, []byte(`{"event":"charge.success","data":{"amount":1}}`), ErrInvalidSignature},
+	} {
+		if err := VerifySignature(tc.key, tc.body, tc.sig); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got %v, want %v", name, err, tc.want)
+		}
+	}
+}
+
+func TestParseTypedEvents(t *testing.T) {
+	events := map[string]string{
+		EventChargeSuccess:                 `{"event":"charge.success","data":{"id":302961,"domain":"live","status":"success","reference":"qTPrJoy9Bx","amount":10000,"gateway_response":"Approved by Financial Institution","paid_at":"2016-09-30T21:10:19.000Z","channel":"card","currency":"NGN","ip_address":"41.242.49.37","metadata":0,"log":null,"fees":null,"customer":{"id":68324,"first_name":"BoJack","last_name":"Horseman","email":"bojack@horseman.com","customer_code":"CUS_qo38as2hpsgk2r0","phone":null,"metadata":null,"risk_action":"default"},"authorization":{"authorization_code":"AUTH_f5rnfq9p","bin":"539999","last4":"8877","exp_month":"08","exp_year":"2020","card_type":"mastercard DEBIT","bank":"Guaranty Trust Bank","country_code":"NG","brand":"mastercard","account_name":"BoJack Horseman"},"plan":{}}}`,
+		EventTransferSuccess:               `{"event":"transfer.success","data":{"amount":30000,"currency":"NGN","domain":"test","failures":null,"id":37272792,"integration":{"id":463433,"is_live":true,"business_name":"Boom Boom Industries NG"},"reason":"Have fun...","reference":"1jhbs3ozmen0k7y5efmw","source":"balance","source_details":null,"status":"success","titan_code":null,"transfer_code":"TRF_wpl1dem4967avzm","transferred_at":null,"recipient":{"active":true,"currency":"NGN","description":"","domain":"test","email":null,"id":8690817,"integration":463433,"metadata":null,"name":"Jack Sparrow","recipient_code":"RCP_a8wkxiychzdzfgs","type":"nuban","is_deleted":false,"details":{"account_number":"0000000000","account_name":null,"bank_code":"011","bank_name":"First Bank of Nigeria"}},"session":{"provider":null,"id":null},"created_at":"2020-10-26T12:28:57.000Z","updated_at":"2020-10-26T12:28:57.000Z"}}`,
+		EventSubscriptionCreate:            `{"event":"subscription.create","data":{"domain":"test","status":"active","subscription_code":"SUB_vsyqdmlzble3uii","amount":50000,"cron_expression":"0 0 28 * *","next_payment_date":"2016-05-19T07:00:00.000Z","open_invoice":null,"createdAt":"2016-03-20T00:23:24.000Z","integration":100032,"plan":{"name":"Monthly retainer","plan_code":"PLN_gx2wn530m0i3w3m","interval":"monthly","amount":50000},"authorization":{"authorization_code":"AUTH_96xphygz"},"customer":{"first_name":"BoJack","email":"bojack@horsinaround.com","customer_code":"CUS_xnxdt6s1zg1f4nx"}}}`,
+		EventInvoiceCreate:                 `{"event":"invoice.create","data":{"domain":"test","invoice_code":"INV_thy2vkmirn2urwv","amount":50000,"period_start":"2018-12-20T15:00:00.000Z","period_end":"2018-12-19T23:59:59.000Z","status":"success","paid":true,"paid_at":"2018-12-20T15:00:00.000Z","description":null,"authorization":{"authorization_code":"AUTH_2e4k18sj52"},"subscription":{"status":"active","subscription_code":"SUB_b5kr6psc3v5i5vi","amount":50000},"customer":{"id":46,"email":"rachel@example.com","customer_code":"CUS_gv0qjglfzrrmqjd"},"transaction":{"reference":"9cfbae6e-bbf3-5b41-8aef-d72c1a17650g","status":"success","amount":50000,"currency":"NGN"},"created_at":"2018-12-20T15:00:02.000Z"}}`,
+		EventRefundProcessed:               `{"event":"refund.processed","data":{"status":"processed","transaction_reference":"tvunjbbd_412829_4b18075d_c7had","refund_reference":"132013318360","amount":"10000","currency":"NGN","processor":"mpgs_zen","customer":{"first_name":"Damilola","last_name":"Odujoko","email":"damilola@example.com"},"integration":412829,"domain":"live"}}`,
+		EventDisputeCreate:                 `{"event":"charge.dispute.create","data":{"id":358950,"refund_amount":5800,"currency":"NGN","status":"awaiting-merchant-feedback","resolution":null,"domain":"live","transaction":{"id":896467688,"domain":"live","status":"success","reference":"v3rb4l_12345","amount":5800,"currency":"NGN"},"transaction_reference":null,"category":"chargeback","customer":{"id":46,"email":"customer@example.com"},"bin":"123456","last4":"1234","dueAt":"2020-11-25T18:00:00.000Z","resolvedAt":null,"evidence":null,"attachments":null,"note":null,"history":[{"status":"pending","by":"demo@test.co","createdAt":"2020-11-24T18:19:18.000Z"}],"messages":[{"sender":"demo@test.co","body":"Customer complained","createdAt":"2020-11-24T18:19:18.000Z"}],"created_at":"2020-11-24T18:19:18.000Z","updated_at":"2020-11-24T18:19:18.000Z","integration":100032}}`,
+		EventDedicatedAccountAssignSuccess: `{"event":"dedicatedaccount.assign.success","data":{"customer":{"id":100110,"first_name":"John","last_name":"Doe","email":"johndoe@test.com","customer_code":"CUS_hcekca0j0bbg2m4","phone":"+2348100000000","metadata":{},"risk_action":"default"},"dedicated_account":{"bank":{"name":"Test Bank","id":20,"slug":"test-bank"},"account_name":"PAYSTACKPRUEBA/JOHN DOE","account_number":"1234567890","assigned":true,"currency":"NGN","metadata":null,"active":true,"id":987654,"created_at":"2022-06-21T17:12:40.000Z","updated_at":"2022-08-12T14:02:51.000Z","assignment":{"assignee_id":100110,"assignee_type":"Customer","account_type":"PAY-WITH-TRANSFER-RECURRING","integration":100043}},"identification":{"status":"success"}}}`,
+		EventCustomerIdentificationFailed:  `{"event":"customeridentification.failed","data":{"customer_id":82796315,"customer_code":"CUS_XXXXXXXXXXXXXXX","email":"email@email.com","identification":{"country":"NG","type":"bank_account","bvn":"123*****456","account_number":"012****345","bank_code":"999991"},"reason":"Account number or BVN is incorrect"}}`,
+	}
+	parsed := map[string]*Event{}
+	for typ, body := range events {
+		ev, err := Parse([]byte(body))
+		if err != nil {
+			t.Fatalf("%s: %v", typ, err)
+		}
+		if ev.Type != typ {
+			t.Errorf("got type %q, want %q", ev.Type, typ)
+		}
+		parsed[typ] = ev
+	}
+
+	txn := parsed[EventChargeSuccess].Data.(*paystack.Transaction)
+	if txn.Reference != "qTPrJoy9Bx" || txn.Amount != paystack.NewMoney(10000, "NGN") || txn.Customer.Email != "bojack@horseman.com" {
+		t.Errorf("got %+v", txn)
+	}
+	trf := parsed[EventTransferSuccess].Data.(*Transfer)
+	if trf.TransferCode != "TRF_wpl1dem4967avzm" || trf.Integration.BusinessName != "Boom Boom Industries NG" || trf.Amount.Currency != "NGN" || trf.CreatedAt.IsZero() {
+		t.Errorf("got %+v", trf)
+	}
+	sub := parsed[EventSubscriptionCreate].Data.(*Subscription)
+	if sub.SubscriptionCode != "SUB_vsyqdmlzble3uii" || sub.Integration.ID != 100032 || sub.CreatedAt.IsZero() {
+		t.Errorf("got %+v", sub)
+	}
+	inv := parsed[EventInvoiceCreate].Data.(*Invoice)
+	if inv.InvoiceCode != "INV_thy2vkmirn2urwv" || !inv.Paid || inv.Transaction.Reference == "" {
+		t.Errorf("got %+v", inv)
+	}
+	refund := parsed[EventRefundProcessed].Data.(*Refund)
+	if refund.Amount != paystack.NewMoney(10000, "NGN") || refund.TransactionReference == "" {
+		t.Errorf("got %+v", refund)
+	}
+	dispute := parsed[EventDisputeCreate].Data.(*Dispute)
+	if dispute.Id != 358950 || dispute.Transaction.Reference != "v3rb4l_12345" || dispute.Customer.Email != "customer@example.com" || dispute.CreatedAt.IsZero() || dispute.DueAt.IsZero() {
+		t.Errorf("got %+v", dispute)
+	}
+	assign := parsed[EventDedicatedAccountAssignSuccess].Data.(*DedicatedAccountAssignment)
+	if assign.DedicatedAccount == nil || assign.DedicatedAccount.AccountNumber != "1234567890" || assign.Identification.Status != "success" {
+		t.Errorf("got %+v", assign)
+	}
+	ident := parsed[EventCustomerIdentificationFailed].Data.(*CustomerIdentification)
+	if ident.CustomerID != "82796315" || ident.Identification.Type != "bank_account" || ident.Reason == "" {
+		t.Errorf("got %+v", ident)
+	}
+}
+
+func TestParseUnknownEvent(t *testing.T) {
+	ev, err := Parse([]byte(`{"event":"paymentrequest.pending","data":{"id":1}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, ok := ev.Data.(map[string]interface{}); !ok || data["id"] != 1.0 {
+		t.Errorf("got %#v, want the data as a map", ev.Data)
+	}
+
+	for _, body := range []string{`not json`, `{"data":{}}`, `{"event":"charge.success","data":{"amount":"lots"}}`} {
+		if _, err := Parse([]byte(body)); err == nil {
+			t.Errorf("%s: expected an error", body)
+		}
+	}
+}